@@ -0,0 +1,47 @@
+// Package main steps through a day 17 program with the debugger REPL.
+//
+//	go run ./cmd/debug17 -a 117440
+//
+// Type help at the prompt for the commands.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jstensland/advent-of-code/2024/day17"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var inPath string
+	var regA int
+	flag.StringVar(&inPath, "in", "./day17/input.txt", "Input file with the registers and program")
+	flag.IntVar(&regA, "a", -1, "Starting value for register A (defaults to the input's)")
+	flag.Parse()
+
+	in, err := os.Open(inPath)
+	if err != nil {
+		return fmt.Errorf("error opening input: %w", err)
+	}
+	defer in.Close()
+
+	computer, err := day17.ParseIn(in)
+	if err != nil {
+		return err
+	}
+	if regA >= 0 {
+		computer.SetRegisterA(regA)
+	}
+
+	debugger := day17.NewDebugger(computer)
+	fmt.Print(debugger.Listing())
+	return debugger.REPL(os.Stdin, os.Stdout)
+}
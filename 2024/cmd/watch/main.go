@@ -0,0 +1,103 @@
+// Package main plays a day's simulation in the terminal, one step at a time.
+//
+//	go run ./cmd/watch -day 15 -wide
+//
+// Space plays and pauses, the arrow keys step forwards and backwards, + and - change the speed
+// and q quits.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/jstensland/advent-of-code/2024/day14"
+	"github.com/jstensland/advent-of-code/2024/day15"
+	"github.com/jstensland/advent-of-code/2024/day6"
+	"github.com/jstensland/advent-of-code/lib/viewer"
+)
+
+// robot room size for day 14
+const (
+	roomHeight = 103
+	roomWidth  = 101
+)
+
+var errUnsupportedDay = errors.New("no simulation for day")
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var day int
+	var inPath string
+	var wide bool
+	flag.IntVar(&day, "day", 15, "Day to watch (6, 14 or 15)")
+	flag.StringVar(&inPath, "in", "", "Input file (defaults to the day's input.txt)")
+	flag.BoolVar(&wide, "wide", false, "Use the wide warehouse from day 15 part 2")
+	flag.Parse()
+
+	if inPath == "" {
+		inPath = fmt.Sprintf("./day%d/input.txt", day)
+	}
+	in, err := os.Open(inPath)
+	if err != nil {
+		return fmt.Errorf("error opening input: %w", err)
+	}
+	defer in.Close()
+
+	v, err := load(day, wide, in)
+	if err != nil {
+		return err
+	}
+
+	restore, err := viewer.Terminal(os.Stdin)
+	if err != nil {
+		return err
+	}
+	defer restore() //nolint:errcheck // nothing more to do if the terminal can't be put back
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := v.Run(ctx, os.Stdin, os.Stdout); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// load sets up a viewer over the day's simulation.
+func load(day int, wide bool, in io.Reader) (*viewer.Viewer, error) {
+	switch day {
+	case 6:
+		layout, err := day6.ParseInput(in)
+		if err != nil {
+			return nil, fmt.Errorf("error loading day 6: %w", err)
+		}
+		return viewer.New(layout.Step, layout.String), nil
+	case 14:
+		room, err := day14.ParseIn(in, roomHeight, roomWidth)
+		if err != nil {
+			return nil, fmt.Errorf("error loading day 14: %w", err)
+		}
+		return viewer.New(func() bool { room.Tick(); return true }, room.String), nil
+	case 15:
+		factor, offset := 1, 0
+		if wide {
+			factor, offset = 2, 1
+		}
+		warehouse, err := day15.ParseIn(in, factor, offset)
+		if err != nil {
+			return nil, fmt.Errorf("error loading day 15: %w", err)
+		}
+		return viewer.New(warehouse.Stepper(wide), warehouse.String), nil
+	}
+	return nil, fmt.Errorf("%w: %d", errUnsupportedDay, day)
+}
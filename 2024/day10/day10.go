@@ -2,13 +2,14 @@
 package day10
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"iter"
-	"log"
 	"slices"
 	"strconv"
+
+	"github.com/jstensland/advent-of-code/lib/geom"
+	"github.com/jstensland/advent-of-code/lib/grid"
 )
 
 const endOfTheRoad = 9
@@ -38,35 +39,33 @@ func SolvePart2(in io.Reader) (int, error) {
 }
 
 type Grid struct {
-	data   [][]int
-	width  int
-	height int
+	data *grid.Grid[int]
 }
 
 // Value returns the value at the given location.
-func (g *Grid) Value(loc Location) int {
-	return g.data[loc.Row][loc.Col]
+func (g *Grid) Value(loc geom.Point) int {
+	return g.data.At(loc)
 }
 
 // IsOnGrid returns true if the location is within the grid.
-func (g *Grid) IsOnGrid(loc Location) bool {
-	return loc.Row >= 0 && loc.Row < g.height && loc.Col >= 0 && loc.Col < g.width
+func (g *Grid) IsOnGrid(loc geom.Point) bool {
+	return g.data.InBounds(loc)
 }
 
 // Score is the method for part 1, counting how many 9s you can get to.
-func (g *Grid) Score(loc Location) int {
+func (g *Grid) Score(loc geom.Point) int {
 	// count how many 9s you can get to
-	locations := g.seekNine(loc, g.data[loc.Row][loc.Col])
-	slices.SortFunc(locations, LocationSort)
+	locations := g.seekNine(loc, g.Value(loc))
+	slices.SortFunc(locations, geom.Compare)
 	return len(slices.Compact(locations))
 }
 
 // Rating is for part 2, counting how many ways you can get to a 9.
-func (g *Grid) Rating(start Location) int {
-	return g.rating(start, g.data[start.Row][start.Col])
+func (g *Grid) Rating(start geom.Point) int {
+	return g.rating(start, g.Value(start))
 }
 
-func (g *Grid) rating(start Location, elevation int) int {
+func (g *Grid) rating(start geom.Point, elevation int) int {
 	// fmt.Println("seeking", start, here)
 	if elevation == endOfTheRoad {
 		return 1
@@ -74,9 +73,9 @@ func (g *Grid) rating(start Location, elevation int) int {
 
 	total := 0
 	next := elevation + 1
-	for _, dir := range []Location{start.Up(), start.Down(), start.Left(), start.Right()} {
-		if g.IsOnGrid(dir) && g.Value(dir) == next {
-			total += g.rating(dir, next)
+	for _, dir := range geom.AllDir4() {
+		if step := start.Move(dir); g.IsOnGrid(step) && g.Value(step) == next {
+			total += g.rating(step, next)
 		}
 	}
 	return total
@@ -84,90 +83,44 @@ func (g *Grid) rating(start Location, elevation int) int {
 
 // seekNine finds walks the grid to find a 9 along an increasing path. It
 // returns a slice of those locations.
-func (g *Grid) seekNine(start Location, here int) []Location {
+func (g *Grid) seekNine(start geom.Point, here int) []geom.Point {
 	if here == endOfTheRoad {
-		return []Location{start}
+		return []geom.Point{start}
 	}
-	var nextSteps []Location
+	var nextSteps []geom.Point
 	next := here + 1
-	for _, dir := range []Location{start.Up(), start.Down(), start.Left(), start.Right()} {
-		if g.IsOnGrid(dir) && g.Value(dir) == next {
-			nextSteps = append(nextSteps, g.seekNine(dir, next)...)
+	for _, dir := range geom.AllDir4() {
+		if step := start.Move(dir); g.IsOnGrid(step) && g.Value(step) == next {
+			nextSteps = append(nextSteps, g.seekNine(step, next)...)
 		}
 	}
 	return nextSteps
 }
 
 func ParseInput(in io.Reader) (*Grid, error) {
-	scanner := bufio.NewScanner(in)
-	grid := Grid{[][]int{}, 0, 0}
-	for scanner.Scan() {
-		inRow := scanner.Text()
-		row := make([]int, len(inRow))
-		for idx, cell := range inRow {
-			if cell == '.' {
-				// for testing
-				row[idx] = -1
-			} else {
-				val, err := strconv.Atoi(string(cell))
-				if err != nil {
-					return nil, fmt.Errorf("error parsing input file: %w", err)
-				}
-				row[idx] = val
-			}
+	data, err := grid.Parse(in, func(cell rune) (int, error) {
+		if cell == '.' {
+			return -1, nil // for testing
 		}
-		grid.data = append(grid.data, row)
-		grid.height++
-	}
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+		val, err := strconv.Atoi(string(cell))
+		if err != nil {
+			return 0, fmt.Errorf("invalid elevation: %w", err)
+		}
+		return val, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing input file: %w", err)
 	}
-
-	grid.width = len(grid.data[0])
-	return &grid, nil
+	return &Grid{data}, nil
 }
 
 // IMPROVEMENT: could record these while parsing rather than dynamically discovered
-func (g *Grid) trailheads() iter.Seq[Location] {
-	return func(yield func(l Location) bool) {
-		for i, row := range g.data {
-			for j, val := range row {
-				if val == 0 {
-					if !yield(Location{i, j}) {
-						return
-					}
-				}
+func (g *Grid) trailheads() iter.Seq[geom.Point] {
+	return func(yield func(l geom.Point) bool) {
+		for _, pos := range grid.FindAll(g.data, 0) {
+			if !yield(pos) {
+				return
 			}
 		}
 	}
 }
-
-type Location struct {
-	Row int
-	Col int
-}
-
-func (l Location) Up() Location    { return Location{l.Row - 1, l.Col} }
-func (l Location) Down() Location  { return Location{l.Row + 1, l.Col} }
-func (l Location) Left() Location  { return Location{l.Row, l.Col - 1} }
-func (l Location) Right() Location { return Location{l.Row, l.Col + 1} }
-
-func LocationSort(a, b Location) int {
-	// treat row as more significant
-	if a.Row < b.Row {
-		return -1
-	}
-	if a.Row > b.Row {
-		return 1
-	}
-
-	// if rows are equal, check columns
-	if a.Col < b.Col {
-		return -1
-	}
-	if a.Col > b.Col {
-		return 1
-	}
-	// all equal
-	return 0
-}
@@ -2,12 +2,13 @@
 package day12
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"iter"
-	"log"
 	"slices"
+
+	"github.com/jstensland/advent-of-code/lib/grid"
+	"github.com/jstensland/advent-of-code/lib/unionfind"
 )
 
 // SolvePart1 finds occurrences of XMAS in a wordsearch fashion.
@@ -34,11 +35,8 @@ func SolvePart2(in io.Reader) (int, error) {
 }
 
 type Grid struct {
-	data         [][]rune
-	lastRegionID int
-	regions      map[int]Region // identifier to region mapping.
-	width        int
-	height       int
+	data    *grid.Grid[rune]
+	regions []Region
 }
 
 func (g *Grid) FencePrice() int {
@@ -57,89 +55,28 @@ func (g *Grid) BulkFencePrice() int {
 	return price
 }
 
-func (g *Grid) Width() int  { return g.width }
-func (g *Grid) Height() int { return g.height }
-func (g *Grid) Get(l Position) rune {
-	if l.row < 0 || l.row >= g.height || l.col < 0 || l.col >= g.width {
-		return rune(0)
-	}
-	return g.data[l.row][l.col]
-}
+func (g *Grid) Width() int  { return g.data.Width() }
+func (g *Grid) Height() int { return g.data.Height() }
 
-func (g *Grid) newRegion(label rune, loc Position) Region {
-	g.lastRegionID++
-	return Region{
-		id:    g.lastRegionID,
-		label: label,
-		area:  []Position{loc},
-	}
+// Get returns the plant at the position, or 0 if it's off the grid.
+func (g *Grid) Get(l Position) rune {
+	return g.data.GetOr(l.pos(), 0)
 }
 
 // FindRegions scans the grid and collects contiguous regions
 func (g *Grid) FindRegions() {
-	// go through each cell in the grid
+	// join every plot with its matching neighbors, then each set of joined plots is a region
+	plots := unionfind.New[Position]()
 	for loc := range g.positions() {
-		g.AddToRegion(loc)
-	}
-}
-
-// AddToRegion takes a location, and decides if it should be added to an existing
-// region, or create a new one. If it is added to an existing region, it will also
-// check if that causes two regions two merge.
-func (g *Grid) AddToRegion(loc Position) {
-	val := g.Get(loc)
-	adjacentRegions := map[int]Region{}
-	// check bordering cells for the same values
-	for _, side := range g.Contiguous(loc) {
-		for id, reg := range g.regions {
-			// check if those cells are in regions
-			// IMPROVEMENT: this lookup is rough. Keep a map of position to region?
-			if val == reg.label && slices.Contains(reg.area, side) {
-				adjacentRegions[id] = reg
-			}
-		}
-	}
-
-	switch len(adjacentRegions) {
-	case 0:
-		// If no bordering regions with the same value, make a new region and store it.
-		newReg := g.newRegion(val, loc)
-		g.regions[newReg.id] = newReg
-	case 1:
-		// if only one bordering region, join it
-		var region Region
-		for _, reg := range adjacentRegions {
-			region = reg // only one iteration
-		}
-		region.area = append(region.area, loc)
-		g.regions[region.id] = region
-	default:
-		// if multiple border regions with this val, merge them
-		g.mergeRegions(adjacentRegions, loc)
-	}
-}
-
-func (g *Grid) mergeRegions(toMerge map[int]Region, loc Position) {
-	first := true
-	var region Region
-	toDelete := make([]int, 0, len(toMerge)-1)
-	for _, reg := range toMerge {
-		if first { // grab the first region
-			region = reg
-			first = false
-			continue
+		plots.Add(loc)
+		for _, side := range g.Contiguous(loc) {
+			plots.Union(loc, side)
 		}
-		toDelete = append(toDelete, reg.id)
-		// add the rest to the first
-		region.area = append(region.area, reg.area...)
 	}
-	// in the current loc
-	region.area = append(region.area, loc)
-	g.regions[region.id] = region // save
 
-	// remove merged regions
-	for _, id := range toDelete {
-		delete(g.regions, id)
+	g.regions = make([]Region, 0, plots.Count())
+	for _, area := range plots.Components() {
+		g.regions = append(g.regions, Region{label: g.Get(area[0]), area: area})
 	}
 }
 
@@ -149,7 +86,7 @@ func (g *Grid) Contiguous(loc Position) []Position {
 
 	val := g.Get(loc)
 	for _, side := range loc.adjacent() {
-		if loc.row < 0 || loc.row >= g.height || loc.col < 0 || loc.col >= g.width {
+		if !g.data.InBounds(side.pos()) {
 			continue // skip if off the grid
 		}
 		if g.Get(side) == val {
@@ -161,11 +98,9 @@ func (g *Grid) Contiguous(loc Position) []Position {
 
 func (g *Grid) positions() iter.Seq[Position] {
 	return func(yield func(r Position) bool) {
-		for row := range g.height {
-			for col := range g.width {
-				if !yield(Position{row, col}) {
-					return
-				}
+		for pos := range g.data.Positions() {
+			if !yield(Position{pos.Row, pos.Col}) {
+				return
 			}
 		}
 	}
@@ -195,7 +130,6 @@ func SortBorders(a, b Border) int {
 }
 
 type Region struct {
-	id    int
 	label rune
 	area  []Position
 }
@@ -307,25 +241,13 @@ func (r Region) StraightSides() int {
 // Input is 140x140, so opted to manipulate it after for flexibility
 // instead of trying to process it as a stream
 func ParseGrid(in io.Reader) (Grid, error) {
-	scanner := bufio.NewScanner(in)
-	grid := Grid{
-		data:    [][]rune{},
-		regions: map[int]Region{},
-		width:   0,
-		height:  0,
-	}
-	for scanner.Scan() {
-		row := []rune(scanner.Text())
-		grid.data = append(grid.data, row)
-		grid.height++
-	}
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+	data, err := grid.Parse(in, grid.Runes)
+	if err != nil {
+		return Grid{}, fmt.Errorf("failed to parse garden: %w", err)
 	}
-
-	grid.width = len(grid.data[0])
-	grid.FindRegions() // populate regions. IMPROVEMENT: make private, use export_test.go if needed
-	return grid, nil
+	garden := Grid{data: data}
+	garden.FindRegions() // populate regions. IMPROVEMENT: make private, use export_test.go if needed
+	return garden, nil
 }
 
 type Position struct {
@@ -335,6 +257,8 @@ type Position struct {
 	col int
 }
 
+func (p Position) pos() grid.Pos { return grid.Pos{Row: p.row, Col: p.col} }
+
 func (p Position) adjacent() []Position {
 	return []Position{
 		{p.row - 1, p.col}, // up
@@ -2,15 +2,14 @@
 package day13
 
 import (
-	"bufio"
-	"bytes"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"math"
-	"regexp"
-	"strconv"
+
+	"github.com/jstensland/advent-of-code/lib/linalg"
+	"github.com/jstensland/advent-of-code/lib/numeric"
+	"github.com/jstensland/advent-of-code/lib/parse"
 )
 
 const (
@@ -94,121 +93,120 @@ func (g *Game) Correct() {
 	g.Prize.Y += 10000000000000
 }
 
-// Solve is a revamp applicable for small and large numbers
+// Solve finds the cheapest presses to reach the prize, for small and large numbers.
+//
+// It solves for g.A.Count and g.B.Count in the following system
+//
+//	g.A.Count*g.A.XDelta + g.B.Count*g.B.XDelta = g.Prize.X
+//	g.A.Count*g.A.YDelta + g.B.Count*g.B.YDelta = g.Prize.Y
+//
+// When the buttons move along the same line there are many answers, so the cheapest is picked.
 func (g *Game) Solve() (int, error) {
-	slopeToPrize := float64(g.Prize.Y) / float64(g.Prize.X)
-
-	// - check if the prize is too high or too low to be reached, drop case
-	if slopeToPrize < g.B.Slope() && slopeToPrize < g.A.Slope() ||
-		slopeToPrize > g.B.Slope() && slopeToPrize > g.A.Slope() {
+	sol, err := linalg.SolveInts(
+		[][]int{{g.A.XDelta, g.B.XDelta}, {g.A.YDelta, g.B.YDelta}},
+		[]int{g.Prize.X, g.Prize.Y},
+	)
+	if errors.Is(err, linalg.ErrInconsistent) {
 		return 0, ErrUnsolvable
 	}
-
-	// Solve for g.A.Count and g.B.Count in the following system
-	//
-	// g.A.Count*g.A.XDelta + g.B.Count*g.B.XDelta = g.Prize.X
-	// g.A.Count*g.A.YDelta + g.B.Count*g.B.YDelta = g.Prize.Y
-
-	g.A.Count = (g.B.XDelta*g.Prize.Y - g.B.YDelta*g.Prize.X) /
-		(g.B.XDelta*g.A.YDelta - g.B.YDelta*g.A.XDelta)
-
-	if (g.B.XDelta*g.Prize.Y-g.B.YDelta*g.Prize.X)%
-		(g.B.XDelta*g.A.YDelta-g.B.YDelta*g.A.XDelta) != 0 {
-		// cannot be done if there is some left over
-		return 0, ErrUnsolvable
+	if err != nil {
+		return 0, fmt.Errorf("error solving game: %w", err)
+	}
+	if !sol.Unique() {
+		return g.solveColinear()
 	}
 
-	g.B.Count = (g.A.XDelta*g.Prize.Y - g.A.YDelta*g.Prize.X) /
-		(g.A.XDelta*g.B.YDelta - g.B.XDelta*g.A.YDelta)
-
-	if (g.A.XDelta*g.Prize.Y-g.A.YDelta*g.Prize.X)%
-		(g.A.XDelta*g.B.YDelta-g.B.XDelta*g.A.YDelta) != 0 {
-		// cannot be done if there is some left over
+	counts, ok := linalg.Ints(sol.Particular)
+	if !ok || counts[0] < 0 || counts[1] < 0 {
+		// cannot be done with part presses, or by pulling the claw back
 		return 0, ErrUnsolvable
 	}
+	g.A.Count, g.B.Count = counts[0], counts[1]
 
 	return g.A.Price() + g.B.Price(), nil
 }
 
-// ParseIn reads in all the games. For solving.
-func ParseIn(in io.Reader) ([]Game, error) {
-	scanner := bufio.NewScanner(in)
-	scanner.Split(splitOnDoubleCR)
-	games := []Game{}
-	// may want to read them all and split on double new lines, or just read until newline
-	// parsing as you go
-	buttonAMatch := regexp.MustCompile(`Button A: X\+(\d+), Y\+(\d+)`)
-	buttonBMatch := regexp.MustCompile(`Button B: X\+(\d+), Y\+(\d+)`)
-	prizeMatch := regexp.MustCompile(`Prize: X=(\d+), Y=(\d+)`)
-
-	for scanner.Scan() {
-		// read the first line as Button A
-		puzzle := scanner.Text()
-		aM := buttonAMatch.FindStringSubmatch(puzzle)
-		xADelta, err := strconv.Atoi(aM[1])
-		if err != nil {
-			return nil, fmt.Errorf("error parsing xDelta: %w", err)
-		}
-		yADelta, err := strconv.Atoi(aM[2])
-		if err != nil {
-			return nil, fmt.Errorf("error parsing yDelta: %w", err)
-		}
+// solveColinear picks the cheapest presses when the buttons and prize are all on one line, so
+// only one equation matters. The cost changes linearly with the presses of A, so the cheapest
+// answer is either the fewest or the most presses of A that still land on the prize.
+func (g *Game) solveColinear() (int, error) {
+	aDelta, bDelta, target := g.A.XDelta, g.B.XDelta, g.Prize.X
+	if aDelta == 0 && bDelta == 0 {
+		// the line is vertical
+		aDelta, bDelta, target = g.A.YDelta, g.B.YDelta, g.Prize.Y
+	}
 
-		bM := buttonBMatch.FindStringSubmatch(puzzle)
-		xBDelta, err := strconv.Atoi(bM[1])
-		if err != nil {
-			return nil, fmt.Errorf("error parsing xDelta: %w", err)
+	switch {
+	case aDelta == 0 && bDelta == 0: // neither button moves the claw
+		g.A.Count, g.B.Count = 0, 0
+	case aDelta == 0: // A does nothing, so never press it
+		if target%bDelta != 0 || target/bDelta < 0 {
+			return 0, ErrUnsolvable
 		}
-		yBDelta, err := strconv.Atoi(bM[2])
-		if err != nil {
-			return nil, fmt.Errorf("error parsing yDelta: %w", err)
+		g.A.Count, g.B.Count = 0, target/bDelta
+	case bDelta == 0: // B does nothing, so never press it
+		if target%aDelta != 0 || target/aDelta < 0 {
+			return 0, ErrUnsolvable
 		}
-
-		prizeM := prizeMatch.FindStringSubmatch(puzzle)
-		prizeX, err := strconv.Atoi(prizeM[1])
-		if err != nil {
-			return nil, fmt.Errorf("error parsing xDelta: %w", err)
+		g.A.Count, g.B.Count = target/aDelta, 0
+	default:
+		// a*aDelta = target mod bDelta. Dividing out the gcd leaves a modulus aDelta is
+		// invertible in, and presses of A that work repeat every step presses.
+		gcd := numeric.GCD(aDelta, bDelta)
+		if target%gcd != 0 {
+			return 0, ErrUnsolvable
 		}
-		prizeY, err := strconv.Atoi(prizeM[2])
+		step := bDelta / gcd
+		inv, err := numeric.ModInverse(aDelta/gcd, step)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing yDelta: %w", err)
+			return 0, fmt.Errorf("error solving colinear game: %w", err)
+		}
+		first := numeric.MulMod(target/gcd, inv, step)
+		if first*aDelta > target {
+			return 0, ErrUnsolvable
 		}
+		most := target / aDelta
+		most -= (most - first) % step
 
-		game := Game{
-			A:     Button{Cost: ACost, XDelta: xADelta, YDelta: yADelta},
-			B:     Button{Cost: BCost, XDelta: xBDelta, YDelta: yBDelta},
-			Prize: Coordinate{X: prizeX, Y: prizeY},
+		cost := func(a int) int { return g.A.Cost*a + g.B.Cost*(target-a*aDelta)/bDelta }
+		best := first
+		if cost(most) < cost(first) {
+			best = most
 		}
-		games = append(games, game)
-	}
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+		g.A.Count, g.B.Count = best, (target-best*aDelta)/bDelta
 	}
 
-	return games, nil
+	return g.A.Price() + g.B.Price(), nil
 }
 
-// implements Splitfunc for the scanner. https://pkg.go.dev/bufio#SplitFunc
-func splitOnDoubleCR(data []byte, atEOF bool) (advance int, token []byte, err error) {
-	if atEOF && len(data) == 0 {
-		return 0, nil, nil // should this return ErrFinalToken?
-	}
+// machine is one claw machine as written in the input
+type machine struct {
+	AX, AY, BX, BY, PrizeX, PrizeY int
+}
 
-	// Find the next double newline
-	splitToken := []byte("\n\n")
-	if i := bytes.Index(data, splitToken); i >= 0 {
-		// We found a double carriage return.
-		// Return the token up to that point.
-		return i + len(splitToken), data[:i], nil
+// ParseIn reads in all the games. For solving.
+func ParseIn(in io.Reader) ([]Game, error) {
+	sections, err := parse.Sections(in)
+	if err != nil {
+		return nil, err
 	}
+	record := parse.MustRecord[machine](`Button A: X\+(\d+), Y\+(\d+)\n` +
+		`Button B: X\+(\d+), Y\+(\d+)\n` +
+		`Prize: X=(\d+), Y=(\d+)`)
 
-	// If we're at EOF, we have a final, non-terminated line. Return it.
-	if atEOF {
-		return len(data), data, nil
+	games := make([]Game, 0, len(sections))
+	for _, section := range sections {
+		m, err := record.DecodeSection(section)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing machine: %w", err)
+		}
+		games = append(games, Game{
+			A:     Button{Cost: ACost, XDelta: m.AX, YDelta: m.AY},
+			B:     Button{Cost: BCost, XDelta: m.BX, YDelta: m.BY},
+			Prize: Coordinate{X: m.PrizeX, Y: m.PrizeY},
+		})
 	}
-
-	// Request more data.
-	return 0, nil, nil
+	return games, nil
 }
 
 // SolveSlow solves the problems by taking individual steps. It was a first iteration and
@@ -152,3 +152,64 @@ func TestRunPart2(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 71493195288102, total) // confirmed
 }
+
+func TestSolveColinear(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		a, b   day13.Coordinate
+		prize  day13.Coordinate
+		cost   int
+		aCount int
+		bCount int
+		err    error
+	}{
+		{
+			desc: "B cheaper per step", a: day13.Coordinate{X: 1, Y: 1}, b: day13.Coordinate{X: 2, Y: 2},
+			prize: day13.Coordinate{X: 10, Y: 10}, cost: 5, aCount: 0, bCount: 5,
+		},
+		{
+			desc: "A cheaper per step", a: day13.Coordinate{X: 4, Y: 4}, b: day13.Coordinate{X: 1, Y: 1},
+			prize: day13.Coordinate{X: 10, Y: 10}, cost: 8, aCount: 2, bCount: 2,
+		},
+		{
+			desc: "A needed to land on it", a: day13.Coordinate{X: 3, Y: 6}, b: day13.Coordinate{X: 2, Y: 4},
+			prize: day13.Coordinate{X: 7, Y: 14}, cost: 5, aCount: 1, bCount: 2,
+		},
+		{
+			desc: "vertical line", a: day13.Coordinate{X: 0, Y: 5}, b: day13.Coordinate{X: 0, Y: 2},
+			prize: day13.Coordinate{X: 0, Y: 20}, cost: 10, aCount: 0, bCount: 10,
+		},
+		{
+			desc: "A does not move", a: day13.Coordinate{X: 0, Y: 0}, b: day13.Coordinate{X: 2, Y: 1},
+			prize: day13.Coordinate{X: 8, Y: 4}, cost: 4, aCount: 0, bCount: 4,
+		},
+		{
+			desc: "on the line but between presses", a: day13.Coordinate{X: 2, Y: 2}, b: day13.Coordinate{X: 4, Y: 4},
+			prize: day13.Coordinate{X: 5, Y: 5}, err: day13.ErrUnsolvable,
+		},
+		{
+			desc: "off the line", a: day13.Coordinate{X: 1, Y: 2}, b: day13.Coordinate{X: 2, Y: 4},
+			prize: day13.Coordinate{X: 3, Y: 3}, err: day13.ErrUnsolvable,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			game := day13.Game{
+				A:     day13.Button{Cost: day13.ACost, XDelta: tC.a.X, YDelta: tC.a.Y},
+				B:     day13.Button{Cost: day13.BCost, XDelta: tC.b.X, YDelta: tC.b.Y},
+				Prize: tC.prize,
+			}
+
+			cost, err := game.Solve()
+
+			if tC.err != nil {
+				require.ErrorIs(t, err, tC.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tC.cost, cost)
+			assert.Equal(t, tC.aCount, game.A.Count)
+			assert.Equal(t, tC.bCount, game.B.Count)
+		})
+	}
+}
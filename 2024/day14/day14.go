@@ -2,13 +2,16 @@
 package day14
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
+	"image/color"
 	"io"
-	"log"
-	"regexp"
 	"slices"
-	"strconv"
+
+	"github.com/jstensland/advent-of-code/lib/grid"
+	"github.com/jstensland/advent-of-code/lib/numeric"
+	"github.com/jstensland/advent-of-code/lib/parse"
+	"github.com/jstensland/advent-of-code/lib/render"
 )
 
 type Quadrant int
@@ -22,6 +25,9 @@ const (
 
 const treePicThreshold = 10
 
+// ErrNoTree is returned when the robots cycle without ever drawing a tree.
+var ErrNoTree = errors.New("no tree found")
+
 // RobotID uniquely identifies a robot. There are only 500.
 type RobotID int
 
@@ -67,36 +73,42 @@ func SolvePart1(in io.Reader, height, width int) (int, error) {
 //
 // First I had height and width switched, so got discouraged. Looked up what a tree should
 // look like. Tried searching outputs for long sequences of XXXXXX and that worked
+//
+// Every robot is back where it started after Period ticks, so there is no point looking further.
 func SolvePart2(in io.Reader, height, width int) (int, error) {
 	grid, err := ParseIn(in, height, width)
 	if err != nil {
 		return 0, fmt.Errorf("error loading input: %w", err)
 	}
 
-	var seconds int
-	i := 1
-	for {
+	period, err := grid.Period()
+	if err != nil {
+		return 0, err
+	}
+	for i := 1; i <= period; i++ {
 		grid.Tick()
-		// if i%1_000 == 0 {
-		// 	// show progress
-		// 	fmt.Println("tick:", i)
-		// }
-
 		if grid.TreeLike(treePicThreshold) {
-			// fmt.Println("tree like tick:", i) // see the answer
 			// fmt.Println(grid) // see the trees
-			seconds = i
-			break
+			return i, nil
 		}
-		i++
 	}
-	return seconds, nil
+	return 0, fmt.Errorf("%w after %d ticks", ErrNoTree, period)
+}
+
+// Period is how many ticks it takes for every robot to be back where it started. Columns
+// repeat every Width ticks and rows every Height ticks, so together they repeat at the LCM.
+func (g *Grid) Period() (int, error) {
+	period, err := numeric.LCM(g.Width, g.Height)
+	if err != nil {
+		return 0, fmt.Errorf("grid too large: %w", err)
+	}
+	return period, nil
 }
 
 func (g *Grid) Tick() {
 	for i := range g.Robots {
-		g.Robots[i].Position.Col = (g.Width + g.Robots[i].Position.Col + g.Robots[i].Velocity.DeltaCol) % g.Width
-		g.Robots[i].Position.Row = (g.Height + g.Robots[i].Position.Row + g.Robots[i].Velocity.DeltaRow) % g.Height
+		g.Robots[i].Position.Col = numeric.Mod(g.Robots[i].Position.Col+g.Robots[i].Velocity.DeltaCol, g.Width)
+		g.Robots[i].Position.Row = numeric.Mod(g.Robots[i].Position.Row+g.Robots[i].Velocity.DeltaRow, g.Height)
 	}
 }
 
@@ -188,6 +200,24 @@ func (g *Grid) String() string {
 	return out
 }
 
+// Snapshot counts the robots on each tile, for rendering.
+func (g *Grid) Snapshot() *grid.Grid[int] {
+	counts := grid.New[int](g.Width, g.Height)
+	for _, robot := range g.Robots {
+		pos := grid.Pos{Row: robot.Position.Row, Col: robot.Position.Col}
+		counts.Set(pos, counts.At(pos)+1)
+	}
+	return counts
+}
+
+// Palette colours a snapshot: dark for an empty tile, green for any robots.
+func Palette() render.Palette[int] {
+	return render.Palette[int]{
+		Colors:  map[int]color.Color{0: color.RGBA{R: 10, G: 20, B: 40, A: 255}},
+		Default: color.RGBA{R: 40, G: 200, B: 80, A: 255},
+	}
+}
+
 // Quadrant returns different quadrants for each quadrant, or -1 if not in a quadrant
 func (g *Grid) Quadrant(p Position) Quadrant {
 	if p.Col == g.Width/2 || p.Row == g.Height/2 {
@@ -230,42 +260,20 @@ func (g *Grid) SafetyFactor() int {
 
 // ParseIn reads the input into a grid of robots
 func ParseIn(in io.Reader, height, width int) (*Grid, error) {
-	scanner := bufio.NewScanner(in)
-	robotID := 0
-	robots := []Robot{}
-
 	// p=2,0 v=2,-1
-	robotInfo := regexp.MustCompile(`p=(\d+),(\d+) v=(-?\d+),(-?\d+)`)
-	for scanner.Scan() {
-		robotID++
-		line := scanner.Text()
-		info := robotInfo.FindStringSubmatch(line)
-		px, err := strconv.Atoi(info[1])
-		if err != nil {
-			return nil, fmt.Errorf("error parsing xDelta: %w", err)
-		}
-		py, err := strconv.Atoi(info[2])
-		if err != nil {
-			return nil, fmt.Errorf("error parsing yDelta: %w", err)
-		}
-		vx, err := strconv.Atoi(info[3])
-		if err != nil {
-			return nil, fmt.Errorf("error parsing xDelta: %w", err)
-		}
-		vy, err := strconv.Atoi(info[4])
-		if err != nil {
-			return nil, fmt.Errorf("error parsing yDelta: %w", err)
-		}
+	record := parse.MustRecord[struct{ PX, PY, VX, VY int }](`p=(\d+),(\d+) v=(-?\d+),(-?\d+)`)
+	infos, err := parse.Lines(in, record.Decode)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing robots: %w", err)
+	}
 
+	robots := make([]Robot, 0, len(infos))
+	for idx, info := range infos {
 		robots = append(robots, Robot{
-			ID:       RobotID(robotID),
-			Position: Position{px, py},
-			Velocity: Velocity{vx, vy},
+			ID:       RobotID(idx + 1),
+			Position: Position{info.PX, info.PY},
+			Velocity: Velocity{info.VX, info.VY},
 		})
 	}
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
-	}
-
 	return &Grid{Robots: robots, Height: height, Width: width}, nil
 }
@@ -1,8 +1,12 @@
 package day14_test
 
 import (
+	"bytes"
+	"image/gif"
+	"image/png"
 	"io"
 	"os"
+	"slices"
 	"strings"
 	"testing"
 
@@ -10,6 +14,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/jstensland/advent-of-code/2024/day14"
+	"github.com/jstensland/advent-of-code/2024/input"
+	"github.com/jstensland/advent-of-code/lib/render"
 )
 
 func example() io.Reader {
@@ -183,3 +189,85 @@ func TestSolvePart2(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 7753, safetyFactor)
 }
+
+func TestPeriod(t *testing.T) {
+	grid, err := day14.ParseIn(example(), 7, 11)
+	require.NoError(t, err)
+	start := slices.Clone(grid.Robots)
+
+	period, err := grid.Period()
+	require.NoError(t, err)
+	assert.Equal(t, 77, period)
+
+	for range period {
+		grid.Tick()
+	}
+	assert.Equal(t, start, grid.Robots, "every robot should be back where it started")
+}
+
+func TestTickFastRobot(t *testing.T) {
+	grid := day14.Grid{
+		Width:  11,
+		Height: 7,
+		Robots: []day14.Robot{
+			{Position: day14.Position{Col: 1, Row: 1}, Velocity: day14.Velocity{DeltaCol: -25, DeltaRow: 15}},
+		},
+	}
+
+	grid.Tick()
+
+	assert.Equal(t, day14.Position{Col: 9, Row: 2}, grid.Robots[0].Position)
+}
+
+func TestSolvePart2_NoTree(t *testing.T) {
+	_, err := day14.SolvePart2(example(), 7, 11)
+
+	require.ErrorIs(t, err, day14.ErrNoTree)
+}
+
+func TestSnapshot(t *testing.T) {
+	grid, err := day14.ParseIn(example(), 7, 11)
+	require.NoError(t, err)
+
+	snap := grid.Snapshot()
+
+	assert.Equal(t, 11, snap.Width())
+	assert.Equal(t, 7, snap.Height())
+	total := 0
+	for _, count := range snap.All() {
+		total += count
+	}
+	assert.Equal(t, 12, total)
+}
+
+func TestRenderTree(t *testing.T) {
+	grid, err := day14.ParseIn(input.Reader("./input.txt"), 103, 101)
+	require.NoError(t, err)
+	for range 7753 {
+		grid.Tick()
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, render.WritePNG(&buf, grid.Snapshot(), day14.Palette(), 2))
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, 202, img.Bounds().Dx())
+	assert.Equal(t, 206, img.Bounds().Dy())
+}
+
+func TestRecordTicks(t *testing.T) {
+	grid, err := day14.ParseIn(example(), 7, 11)
+	require.NoError(t, err)
+	rec, err := render.NewRecorder(day14.Palette(), 8, 20)
+	require.NoError(t, err)
+
+	frames := rec.Record(grid.Snapshot, func() bool { grid.Tick(); return true }, 5)
+
+	assert.Equal(t, 6, frames)
+	var buf bytes.Buffer
+	require.NoError(t, rec.WriteGIF(&buf))
+	anim, err := gif.DecodeAll(&buf)
+	require.NoError(t, err)
+	assert.Len(t, anim.Image, 6)
+}
@@ -7,31 +7,34 @@ import (
 	"fmt"
 	"io"
 	"log"
+
+	"github.com/jstensland/advent-of-code/lib/geom"
+	"github.com/jstensland/advent-of-code/lib/grid"
 )
 
 var ErrUnknownInput = errors.New("unknown input character")
 
 func SolvePart1(in io.Reader) (int, error) {
-	grid, err := ParseIn(in, 1, 0)
+	warehouse, err := ParseIn(in, 1, 0)
 	if err != nil {
 		return 0, fmt.Errorf("error loading input: %w", err)
 	}
-	// fmt.Println(grid)
-	grid.RunRobotsV1()
-	// fmt.Println(grid)
-	return grid.TotalGPS(), nil
+	// fmt.Println(warehouse)
+	warehouse.RunRobotsV1()
+	// fmt.Println(warehouse)
+	return warehouse.TotalGPS(), nil
 }
 
 // RunRobotsV1 moves the robot all the moves. affecting the grid
 func (g *Grid) RunRobotsV1() {
-	for _, mv := range g.movesVec {
+	for _, mv := range g.moves {
 		// fmt.Println(g)
 		// fmt.Println(mv)
 		g.maybeMoveV1(mv)
 	}
 }
 
-func (g *Grid) maybeMoveV1(mv MoveVector) {
+func (g *Grid) maybeMoveV1(mv geom.Dir4) {
 	g.doMoveV1(mv, g.robotLoc)
 }
 
@@ -41,7 +44,7 @@ func (g *Grid) maybeMoveV1(mv MoveVector) {
 // Blocks are checked and moved as a unit.
 //
 // The return value indicates if the move was possible.
-func (g *Grid) doMoveV1(mv MoveVector, currentLoc Location) bool {
+func (g *Grid) doMoveV1(mv geom.Dir4, currentLoc Location) bool {
 	currentVal := g.GetLoc(currentLoc)
 	if currentVal == Wall {
 		return false // can't move a wall
@@ -49,7 +52,7 @@ func (g *Grid) doMoveV1(mv MoveVector, currentLoc Location) bool {
 	if currentVal == Empty {
 		return true // noop to move an empty spot, but possible, so true
 	}
-	nextLoc := currentLoc.Add(mv)
+	nextLoc := currentLoc.Move(mv)
 	nextVal := g.GetLoc(nextLoc)
 	if nextVal == Wall {
 		// if the next position is a wall, can't go. Do nothing and return false.
@@ -82,19 +85,15 @@ func (g *Grid) TotalGPS() int {
 	// fmt.Println(g)
 	total := 0
 	// for each box
-	for row := range g.Height {
-		for col := range g.Width {
-			if g.data[row][col] == Box {
-				total += 100*row + col //nolint:mnd // magic number
-			}
-		}
+	for _, pos := range grid.FindAll(g.data, Box) {
+		total += 100*pos.Row + pos.Col //nolint:mnd // magic number
 	}
 	return total
 }
 
 // ParseIn reads the input into a grid of robots
 func ParseIn(in io.Reader, widthFactor, offset int) (*Grid, error) {
-	grid := make([][]State, 0)
+	rows := make([][]State, 0)
 	var robotRow int
 	var robotCol int
 	scanner := bufio.NewScanner(in)
@@ -111,9 +110,9 @@ func ParseIn(in io.Reader, widthFactor, offset int) (*Grid, error) {
 		}
 		if maybeCol != nil {
 			robotCol = *maybeCol
-			robotRow = len(grid)
+			robotRow = len(rows)
 		}
-		grid = append(grid, newRow)
+		rows = append(rows, newRow)
 	}
 
 	// Read the robot moves in
@@ -126,12 +125,17 @@ func ParseIn(in io.Reader, widthFactor, offset int) (*Grid, error) {
 		log.Fatal(err)
 	}
 
+	data, err := grid.FromRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("invalid warehouse: %w", err)
+	}
+
 	return &Grid{
-		data:     grid,
+		data:     data,
 		robotLoc: Location{robotRow, robotCol},
-		movesVec: robotVectMoves,
-		Width:    len(grid[0]),
-		Height:   len(grid),
+		moves:    robotVectMoves,
+		Width:    data.Width(),
+		Height:   data.Height(),
 	}, nil
 }
 
@@ -170,24 +174,18 @@ func parseRow(inRow string, widthFactor, offset int) ([]State, *int, error) {
 }
 
 // parseMoves reads the robot moves from the rest of the scanner
-func parseMoves(scanner *bufio.Scanner) ([]MoveVector, error) {
+func parseMoves(scanner *bufio.Scanner) ([]geom.Dir4, error) {
 	// Read the robot moves in
-	moveVecMap := map[rune]MoveVector{
-		'^': upVec,
-		'>': rightVec,
-		'<': leftVec,
-		'v': downVec,
-	}
-	robotMoveVector := []MoveVector{}
+	robotMoves := []geom.Dir4{}
 	for scanner.Scan() {
 		allMoves := scanner.Text()
 		for _, chr := range allMoves {
-			mvVec, ok := moveVecMap[chr]
-			if !ok {
-				return nil, fmt.Errorf("unexpected robot move %v: %w", chr, ErrUnknownInput)
+			move, err := geom.ParseDir4(chr)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected robot move: %w", err)
 			}
-			robotMoveVector = append(robotMoveVector, mvVec)
+			robotMoves = append(robotMoves, move)
 		}
 	}
-	return robotMoveVector, nil
+	return robotMoves, nil
 }
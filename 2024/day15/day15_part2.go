@@ -3,29 +3,28 @@ package day15
 import (
 	"fmt"
 	"io"
+
+	"github.com/jstensland/advent-of-code/lib/geom"
+	"github.com/jstensland/advent-of-code/lib/grid"
 )
 
 func SolvePart2(in io.Reader) (int, error) {
 	widthFactor := 2
 	offset := widthFactor - 1
-	grid, err := ParseIn(in, widthFactor, offset)
+	warehouse, err := ParseIn(in, widthFactor, offset)
 	if err != nil {
 		return 0, fmt.Errorf("error loading input: %w", err)
 	}
-	grid.RunRobotsV2()
+	warehouse.RunRobotsV2()
 
-	return grid.TotalGPSV2(), nil
+	return warehouse.TotalGPSV2(), nil
 }
 
 func (g *Grid) TotalGPSV2() int {
 	total := 0
-	for row := range g.Height {
-		for col := range g.Width {
-			// find each box by its left side
-			if g.data[row][col] == BoxLeft {
-				total += 100*row + col //nolint:mnd // magic number
-			}
-		}
+	// find each box by its left side
+	for _, pos := range grid.FindAll(g.data, BoxLeft) {
+		total += 100*pos.Row + pos.Col //nolint:mnd // magic number
 	}
 	return total
 }
@@ -33,7 +32,7 @@ func (g *Grid) TotalGPSV2() int {
 // RunRobotsV2 moves the robot all the moves but treats BoxLeft and BoxRight squares as one
 // ridged body.
 func (g *Grid) RunRobotsV2() {
-	for _, mv := range g.movesVec {
+	for _, mv := range g.moves {
 		// fmt.Println(g)
 		// fmt.Println(mv)
 		g.maybeMoveV2(mv)
@@ -41,11 +40,11 @@ func (g *Grid) RunRobotsV2() {
 	// fmt.Println(g)
 }
 
-func (g *Grid) maybeMoveV2(mv MoveVector) {
+func (g *Grid) maybeMoveV2(mv geom.Dir4) {
 	g.doMove(mv, g.robotLoc)
 }
 
-func (g *Grid) maybeMoveDoubleBox(currentLoc Location, mv MoveVector) bool {
+func (g *Grid) maybeMoveDoubleBox(currentLoc Location, mv geom.Dir4) bool {
 	currentVal := g.GetLoc(currentLoc)
 	// now for the boxes...
 	var boxLoc boxLocation
@@ -56,16 +55,16 @@ func (g *Grid) maybeMoveDoubleBox(currentLoc Location, mv MoveVector) bool {
 	}
 
 	// move block
-	if mv == upVec || mv == downVec {
+	if mv == geom.Up || mv == geom.Down {
 		return g.maybeMoveDoubleBoxVert(boxLoc, mv)
 	}
 
 	// move blocks right and left
 	var afterBoxLoc Location
-	if mv == leftVec {
-		afterBoxLoc = boxLoc.left.Add(mv)
+	if mv == geom.Left {
+		afterBoxLoc = boxLoc.left.Move(mv)
 	} else {
-		afterBoxLoc = boxLoc.right.Add(mv)
+		afterBoxLoc = boxLoc.right.Move(mv)
 	}
 	if g.doMove(mv, afterBoxLoc) {
 		g.moveBoxV2(boxLoc, mv)
@@ -74,9 +73,9 @@ func (g *Grid) maybeMoveDoubleBox(currentLoc Location, mv MoveVector) bool {
 	return false // box didn't move, so can't move this one
 }
 
-func (g *Grid) maybeMoveDoubleBoxVert(boxLoc boxLocation, mv MoveVector) bool {
-	nextLeft := boxLoc.left.Add(mv)
-	nextRight := boxLoc.right.Add(mv)
+func (g *Grid) maybeMoveDoubleBoxVert(boxLoc boxLocation, mv geom.Dir4) bool {
+	nextLeft := boxLoc.left.Move(mv)
+	nextRight := boxLoc.right.Move(mv)
 	// if the value directly above the left side of the box is the left side of another box, boxes
 	// are aligned and there is only one to move
 	if g.GetLoc(nextLeft) == BoxLeft {
@@ -101,7 +100,7 @@ func (g *Grid) maybeMoveDoubleBoxVert(boxLoc boxLocation, mv MoveVector) bool {
 // Blocks are checked and moved as a unit.
 //
 // The return value indicates if the move was possible.
-func (g *Grid) doMove(mv MoveVector, currentLoc Location) bool {
+func (g *Grid) doMove(mv geom.Dir4, currentLoc Location) bool {
 	currentVal := g.GetLoc(currentLoc)
 	if currentVal == Wall {
 		return false
@@ -110,7 +109,7 @@ func (g *Grid) doMove(mv MoveVector, currentLoc Location) bool {
 		return true // noop to move an empty spot, but possible, so true
 	}
 
-	nextLoc := currentLoc.Add(mv)
+	nextLoc := currentLoc.Move(mv)
 	nextVal := g.GetLoc(nextLoc)
 	if nextVal == Wall {
 		// if the next position is a wall, can't go. Do nothing and return false.
@@ -133,50 +132,50 @@ func (g *Grid) doMove(mv MoveVector, currentLoc Location) bool {
 //
 // IMPROVEMENT: it could just check, rather than do, which would avoid the copy, but would
 // need recursive logic similar to doMove
-func doable(g *Grid, mv MoveVector, boxLoc boxLocation) bool {
+func doable(g *Grid, mv geom.Dir4, boxLoc boxLocation) bool {
 	dup := g.Copy()
-	return dup.doMove(mv, boxLoc.left.Add(mv)) && dup.doMove(mv, boxLoc.right.Add(mv))
+	return dup.doMove(mv, boxLoc.left.Move(mv)) && dup.doMove(mv, boxLoc.right.Move(mv))
 }
 
 // moveBoxV1 moves the box according to the vector. The spot it is moving to must be empty
 // or it will panic.
-func (g *Grid) moveBoxV1(current Location, vec MoveVector) {
-	next := current.Add(vec)
+func (g *Grid) moveBoxV1(current Location, vec geom.Dir4) {
+	next := current.Move(vec)
 	if g.GetLoc(next) != Empty {
 		panic("trying to move box up/down into an object") // programmer error
 	}
 	// clear previous spots
-	g.data[current.Row][current.Col] = Empty
-	g.data[next.Row][next.Col] = Box
+	g.setLoc(current, Empty)
+	g.setLoc(next, Box)
 }
 
 // moveBoxV2 moves the box according to the vector. The spot it is moving to must be empty
 // or it will panic.
-func (g *Grid) moveBoxV2(current boxLocation, vec MoveVector) {
-	nextLeft := current.left.Add(vec)
-	nextRight := current.right.Add(vec)
+func (g *Grid) moveBoxV2(current boxLocation, vec geom.Dir4) {
+	nextLeft := current.left.Move(vec)
+	nextRight := current.right.Move(vec)
 	switch vec {
-	case upVec, downVec:
+	case geom.Up, geom.Down:
 		// up/down
 		if g.GetLoc(nextLeft) != Empty || g.GetLoc(nextRight) != Empty {
 			panic("trying to move box up/down into an object") // programmer error
 		}
 		// clear previous spots
-		g.data[current.left.Row][current.left.Col] = Empty
-		g.data[current.right.Row][current.right.Col] = Empty
-	case rightVec:
+		g.setLoc(current.left, Empty)
+		g.setLoc(current.right, Empty)
+	case geom.Right:
 		if g.GetLoc(nextRight) != Empty {
 			panic("trying to move box right into an object") // programmer error
 		}
-		g.data[current.left.Row][current.left.Col] = Empty // clear previous spot
-	case leftVec:
+		g.setLoc(current.left, Empty) // clear previous spot
+	case geom.Left:
 		if g.GetLoc(nextLeft) != Empty {
 			panic("trying to move box left into an object") // programmer error
 		}
-		g.data[current.right.Row][current.right.Col] = Empty // clear previous spot
+		g.setLoc(current.right, Empty) // clear previous spot
 	}
-	g.data[nextLeft.Row][nextLeft.Col] = BoxLeft
-	g.data[nextRight.Row][nextRight.Col] = BoxRight
+	g.setLoc(nextLeft, BoxLeft)
+	g.setLoc(nextRight, BoxRight)
 }
 
 // moveRobot moves the robot. The spot it is moving to must be empty
@@ -185,7 +184,7 @@ func (g *Grid) moveRobot(current, next Location) {
 	if g.GetLoc(next) != Empty {
 		panic("trying to move robot into an object") // programmer error
 	}
-	g.data[next.Row][next.Col] = Robot
-	g.data[current.Row][current.Col] = Empty
+	g.setLoc(next, Robot)
+	g.setLoc(current, Empty)
 	g.robotLoc = next
 }
@@ -1,6 +1,8 @@
 package day15_test
 
 import (
+	"bytes"
+	"image/gif"
 	"io"
 	"os"
 	"strings"
@@ -10,6 +12,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/jstensland/advent-of-code/2024/day15"
+	"github.com/jstensland/advent-of-code/lib/render"
+	"github.com/jstensland/advent-of-code/lib/viewer"
 )
 
 func example() io.Reader {
@@ -314,3 +318,47 @@ func TestPart2(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 1446175, total) // Confirmed
 }
+
+func TestRecordStepper(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		factor   int
+		offset   int
+		wide     bool
+		totalGPS func(*day15.Grid) int
+		want     int
+	}{
+		{desc: "part 1", factor: 1, offset: 0, totalGPS: (*day15.Grid).TotalGPS, want: 10092},
+		{desc: "part 2", factor: 2, offset: 1, wide: true, totalGPS: (*day15.Grid).TotalGPSV2, want: 9021},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			grid, err := day15.ParseIn(example(), tC.factor, tC.offset)
+			require.NoError(t, err)
+			rec, err := render.NewRecorder(day15.Palette(), 2, 2)
+			require.NoError(t, err)
+
+			frames := rec.Record(grid.Snapshot, grid.Stepper(tC.wide), len(grid.Moves())+10)
+
+			assert.Equal(t, len(grid.Moves())+1, frames, "a frame to start and one per move")
+			assert.Equal(t, tC.want, tC.totalGPS(grid), "stepping should end like running all the moves")
+			var buf bytes.Buffer
+			require.NoError(t, rec.WriteGIF(&buf))
+			anim, err := gif.DecodeAll(&buf)
+			require.NoError(t, err)
+			assert.Equal(t, grid.Width*2, anim.Config.Width)
+		})
+	}
+}
+
+func TestViewerHeadless(t *testing.T) {
+	grid, err := day15.ParseIn(example(), 2, 1)
+	require.NoError(t, err)
+	done := grid.Copy()
+	done.RunRobotsV2()
+
+	frames := viewer.New(grid.Stepper(true), grid.String).Headless(len(grid.Moves()) + 10)
+
+	require.Len(t, frames, len(grid.Moves())+1, "a frame to start and one per move")
+	assert.Equal(t, done.String(), frames[len(frames)-1])
+}
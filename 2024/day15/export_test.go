@@ -1,8 +1,10 @@
 package day15
 
+import "github.com/jstensland/advent-of-code/lib/geom"
+
 // Moves exposes parsed grid moves for tests
-func (g *Grid) Moves() []MoveVector {
-	return g.movesVec
+func (g *Grid) Moves() []geom.Dir4 {
+	return g.moves
 }
 
 func (g *Grid) RobotLocationV2() Location {
@@ -1,5 +1,13 @@
 package day15
 
+import (
+	"image/color"
+
+	"github.com/jstensland/advent-of-code/lib/geom"
+	"github.com/jstensland/advent-of-code/lib/grid"
+	"github.com/jstensland/advent-of-code/lib/render"
+)
+
 type State int
 
 func (s State) String() string {
@@ -23,45 +31,73 @@ const (
 )
 
 type Grid struct {
-	data     [][]State
+	data     *grid.Grid[State]
 	robotLoc Location
-	movesVec []MoveVector
+	moves    []geom.Dir4
 	Width    int
 	Height   int
 }
 
 func (g *Grid) GetLoc(l Location) State {
-	return g.data[l.Row][l.Col]
+	return g.data.At(grid.Pos(l))
+}
+
+func (g *Grid) setLoc(l Location, s State) {
+	g.data.Set(grid.Pos(l), s)
 }
 
 func (g *Grid) String() string {
-	out := ""
-	for row := range g.Height {
-		for col := range g.Width {
-			out += g.data[row][col].String()
+	return g.data.String()
+}
+
+// Snapshot is a copy of the warehouse, for rendering.
+func (g *Grid) Snapshot() *grid.Grid[State] {
+	return g.data.Clone()
+}
+
+// Palette colours a warehouse snapshot for rendering.
+func Palette() render.Palette[State] {
+	box := color.RGBA{R: 200, G: 140, B: 60, A: 255}
+	return render.Palette[State]{
+		Colors: map[State]color.Color{
+			Robot:    color.RGBA{R: 230, G: 50, B: 50, A: 255},
+			Wall:     color.RGBA{R: 120, G: 120, B: 120, A: 255},
+			Box:      box,
+			BoxLeft:  box,
+			BoxRight: box,
+			Empty:    color.RGBA{R: 20, G: 20, B: 20, A: 255},
+		},
+	}
+}
+
+// Stepper returns a step function that makes the next robot move each call, treating boxes as
+// wide if wide is set, and returns false once the moves run out. For rendering.
+func (g *Grid) Stepper(wide bool) func() bool {
+	next := 0
+	return func() bool {
+		if next == len(g.moves) {
+			return false
+		}
+		if wide {
+			g.maybeMoveV2(g.moves[next])
+		} else {
+			g.maybeMoveV1(g.moves[next])
 		}
-		out += "\n"
+		next++
+		return true
 	}
-	return out
 }
 
 // Copy returns a copy of the grid.
 func (g *Grid) Copy() *Grid {
 	out := Grid{
-		data:     [][]State{},
+		data:     g.data.Clone(),
 		robotLoc: g.robotLoc,
-		movesVec: []MoveVector{},
+		moves:    []geom.Dir4{},
 		Width:    g.Width,
 		Height:   g.Height,
 	}
-	out.movesVec = append(out.movesVec, g.movesVec...)
-	for row := range g.Height {
-		newRow := make([]State, g.Width)
-		for col := range g.Width {
-			newRow[col] = g.data[row][col]
-		}
-		out.data = append(out.data, newRow)
-	}
+	out.moves = append(out.moves, g.moves...)
 	return &out
 }
 
@@ -70,9 +106,9 @@ type Location struct {
 	Col int
 }
 
-// Add will add a vector to a location and returns the resulting location.
-func (loc Location) Add(vec MoveVector) Location {
-	return Location{Row: loc.Row + vec.deltaRow, Col: loc.Col + vec.deltaCol}
+// Move returns the location one step in the given direction.
+func (loc Location) Move(dir geom.Dir4) Location {
+	return Location(geom.Point(loc).Move(dir))
 }
 
 // boxLoc is the two sides of a box
@@ -80,25 +116,3 @@ type boxLocation struct {
 	left  Location
 	right Location
 }
-
-type MoveVector struct {
-	deltaRow int
-	deltaCol int
-}
-
-//nolint:gochecknoglobals // reference sentinel values for possilbe moves
-var (
-	upVec    = MoveVector{-1, 0}
-	rightVec = MoveVector{0, 1}
-	downVec  = MoveVector{1, 0}
-	leftVec  = MoveVector{0, -1}
-)
-
-func (mv MoveVector) String() string {
-	return map[MoveVector]string{
-		upVec:    "^",
-		rightVec: ">",
-		downVec:  "v",
-		leftVec:  "<",
-	}[mv]
-}
@@ -3,8 +3,9 @@ package day16
 import (
 	"fmt"
 	"io"
-	"slices"
-	"sort"
+	"iter"
+
+	"github.com/jstensland/advent-of-code/lib/shortest"
 )
 
 const (
@@ -22,25 +23,14 @@ func SolvePart1(in io.Reader) (int, error) {
 }
 
 // BestRoute looks for the best route from the start to the end and returns
-// its "score." A move forward costs 1, and a turn costs 1000
-//
-// The general algorithm is to take a step and searh all paths from there, start
-// with the cheapest. Once a successful path is found, subsequent searches will give
-// up after they have a total cost equal to the currently best path
-//
-// Optimizations... if you get to a position that's been checked, stop.
-// - if cost is less, calculate the new best
-// - if the cost is worse, ignore
-//
-// May want to track successful paths for debugging purposes...
+// its "score." A move forward costs 1, and a turn costs 1000. It returns -1 if
+// there's no way to the end.
 func (g *Grid) BestRoute() int {
-	// to start, we need to check all 4 directions, so check one ahead
-	backward := g.search(g.Start.Right().Right().Forward(), 0)
-	minCost := g.search(g.Start, 0)
-	if backward > 0 && backward < minCost {
-		return backward
+	cost, ok := g.search().BestCost()
+	if !ok {
+		return -1
 	}
-	return minCost
+	return cost
 }
 
 func SolvePart2(in io.Reader) (int, error) {
@@ -52,79 +42,41 @@ func SolvePart2(in io.Reader) (int, error) {
 	return grid.BestSeats(), nil
 }
 
+// BestSeats counts the tiles that are on at least one of the best routes.
 func (g *Grid) BestSeats() int {
-	// to start, we need to check all 4 directions, so check one ahead
-	backward := g.search(g.Start.Right().Right().Forward(), 0)
-	minCost := g.search(g.Start, 0)
-	if backward > 0 && backward < minCost {
-		minCost = backward
+	seats := map[Location]bool{}
+	for pos := range g.search().OnBestPaths() {
+		seats[pos.Location] = true // facing doesn't matter for a seat
 	}
-
-	// now that we know the min cost, do the same thing, but record all the
-	// locations on the min cost route...
-	// This definitely relies on internal state of the grid, which isn't ideal, but is
-	// quite efficient the second time because the only the leastCost route continues
-	// due to accumlated state from the previous run
-	g.minAnswer = minCost
-	g.search(g.Start.Right().Right().Forward(), 0)
-	g.search(g.Start, 0)
-	return len(g.counted)
+	return len(seats)
 }
 
-// search will recursively search the next steps for each node and return the cost
-// of the current position plus getting to the next place
-func (g *Grid) search(pos Position, cost int) int {
-	// if it's a wall, this is an impossible move
-	if g.GetLoc(pos) == Wall {
-		return -1
-	}
-	// if you've been here before for less, skip it
-	if lastCost, ok := g.visited[pos]; ok && lastCost < cost {
-		return -1 // got here for less some other way
-	}
-	g.visited[pos] = cost
+// search finds every cheapest route from the start to the end, in any orientation.
+//
+// Each state is a tile and the way the reindeer faces. Turning in place is a move
+// of its own, so the search doesn't need to look ahead past corners.
+func (g *Grid) search() *shortest.Result[Position] {
+	atEnd := func(pos Position) bool { return pos.Location == g.End.Location }
+	return shortest.Dijkstra([]Position{g.Start}, g.moves, atEnd)
+}
 
-	// check if the cost is already beyond the known cheapest. Quit if so
-	if g.leastCost > 0 && cost > g.leastCost {
-		return -1
-	}
-	// check if you're at the ending location. Return accumulated cost if so.
-	if pos == g.End {
-		if cost < g.leastCost {
-			g.leastCost = cost
+// moves lists where the reindeer can go from a position, and what it costs.
+func (g *Grid) moves(pos Position) iter.Seq2[Position, int] {
+	return func(yield func(Position, int) bool) {
+		if forward := pos.Forward(); g.GetLoc(forward) != Wall {
+			if !yield(forward, moveCost) {
+				return
+			}
 		}
-
-		// for the second run...
-		if cost == g.minAnswer {
-			g.counted[pos.Location] = true // count the ending spot
+		if !yield(pos.Right(), turnCost) {
+			return
 		}
-
-		return cost
-	}
-
-	// search cost from forward, right and left and select the lowest
-	costs := []int{
-		g.search(pos.Forward(), cost+moveCost),
-		g.search(pos.Right().Forward(), cost+moveCost+turnCost),
-		g.search(pos.Left().Forward(), cost+moveCost+turnCost),
+		yield(pos.Left(), turnCost)
 	}
-	nextCost := leastCost(costs)
-	if nextCost == g.minAnswer {
-		g.counted[pos.Location] = true
-	}
-	return nextCost
 }
 
-// leastCost finds the samllest cost that's not -1. Returns -1 if no such cost
-func leastCost(costs []int) int {
-	tmp := make([]int, len(costs)) // sort a copy for no side effects
-	copy(tmp, costs)
-	sort.Ints(tmp)
-	idx := slices.IndexFunc(tmp, func(n int) bool {
-		return n > 0
-	})
-	if idx == -1 {
-		return -1
-	}
-	return tmp[idx]
-}
+// recursive DFS, run twice for part 2
+// ok      github.com/jstensland/advent-of-code/2024/day16 79.473s
+//
+// Dijkstra, keeping every predecessor tied for the best cost
+// ok      github.com/jstensland/advent-of-code/2024/day16 0.181s
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/jstensland/advent-of-code/2024/day16"
+	"github.com/jstensland/advent-of-code/lib/geom"
 )
 
 func example() io.Reader {
@@ -57,8 +58,8 @@ func TestParseExample(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 15, grid.Width)
 	assert.Equal(t, 15, grid.Height)
-	assert.Equal(t, day16.Position{day16.Location{13, 1}, day16.East}, grid.Start)
-	assert.Equal(t, day16.Position{day16.Location{1, 13}, day16.North}, grid.End)
+	assert.Equal(t, day16.Position{day16.Location{13, 1}, geom.East}, grid.Start)
+	assert.Equal(t, day16.Position{day16.Location{1, 13}, geom.North}, grid.End)
 }
 
 func TestExampleCost(t *testing.T) {
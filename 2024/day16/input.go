@@ -2,11 +2,12 @@
 package day16
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
-	"log"
+
+	"github.com/jstensland/advent-of-code/lib/geom"
+	"github.com/jstensland/advent-of-code/lib/grid"
 )
 
 var ErrUnknownInput = errors.New("unknown input character")
@@ -20,20 +21,9 @@ const (
 	Empty
 )
 
-type Orientation int
-
-const numDirections = 4
-
-const (
-	North Orientation = iota
-	East
-	South
-	West
-)
-
 type Position struct {
 	Location
-	Direction Orientation
+	Direction geom.Dir4
 }
 
 type Location struct {
@@ -41,146 +31,72 @@ type Location struct {
 	Col int
 }
 
-// Forward will add a vector to a location and returns the resulting location.
+// Forward moves one step in the direction the position is facing.
 func (loc Position) Forward() Position {
-	var mv Move
-	switch loc.Direction {
-	case North:
-		mv = Move{deltaEast: 0, deltaSouth: -1}
-	case South:
-		mv = Move{deltaEast: 0, deltaSouth: 1}
-	case East:
-		mv = Move{deltaEast: 1, deltaSouth: 0}
-	case West:
-		mv = Move{deltaEast: -1, deltaSouth: 0}
-	}
 	return Position{
-		Location: Location{
-			Row: loc.Row + mv.deltaSouth,
-			Col: loc.Col + mv.deltaEast,
-		},
+		Location:  Location(geom.Point(loc.Location).Move(loc.Direction)),
 		Direction: loc.Direction,
 	}
 }
 
 // Right returns the same position, turned to the right.
 func (loc Position) Right() Position {
-	return Position{
-		Location: Location{
-			Row: loc.Row, Col: loc.Col,
-		},
-		Direction: (loc.Direction + 1) % numDirections,
-	}
+	return Position{Location: loc.Location, Direction: loc.Direction.Clockwise()}
 }
 
 // Left returns the same position, turned to the left..
 func (loc Position) Left() Position {
-	return Position{
-		Location: Location{
-			Row: loc.Row, Col: loc.Col,
-		},
-		Direction: ((loc.Direction - 1) + numDirections) % numDirections,
-	}
-}
-
-type Move struct {
-	deltaEast  int
-	deltaSouth int
+	return Position{Location: loc.Location, Direction: loc.Direction.CounterClockwise()}
 }
 
 type Grid struct {
-	data [][]State
+	data *grid.Grid[State]
 	// ReindeerLocation Location
-	Start     Position
-	End       Position
-	Width     int
-	Height    int
-	leastCost int
-
-	visited map[Position]int
-
-	minAnswer int //  should not be Grid state, just experimenting
-	counted   map[Location]bool
+	Start  Position
+	End    Position
+	Width  int
+	Height int
 }
 
 func (g *Grid) GetLoc(l Position) State {
 	// if you're off the grid some how, it's basically a wall, but maps should
 	// avoid this themselves
-	if l.Row < 0 || l.Row >= g.Height || l.Col < 0 || l.Col >= g.Width {
-		return Wall
-	}
-	return g.data[l.Row][l.Col]
+	return g.data.GetOr(grid.Pos(l.Location), Wall)
 }
 
 // ParseIn reads the input into a grid.
 func ParseIn(in io.Reader) (*Grid, error) {
-	data := make([][]State, 0)
-	scanner := bufio.NewScanner(in)
-	var startCol int
-	var startRow int
-	var endCol int
-	var endRow int
-
-	// Read the grid out of the reader
-	for scanner.Scan() {
-		inRow := scanner.Text()
-		if len(inRow) == 0 {
-			break // blank line means we hit the end
-		}
-		newRow, maybeStartCol, maybeEndCol, err := parseRow(inRow)
-		if err != nil {
-			return nil, err
-		}
-		if maybeStartCol != nil {
-			startCol = *maybeStartCol
-			startRow = len(data)
-		}
-		if maybeEndCol != nil {
-			endCol = *maybeEndCol
-			endRow = len(data)
-		}
-		data = append(data, newRow)
+	data, err := grid.Parse(in, parseCell)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse maze: %w", err)
 	}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
-	}
+	start, _ := grid.Find(data, Start)
+	end, _ := grid.Find(data, End)
+	// the start and end are open spaces, tracked separately
+	data.Set(start, Empty)
+	data.Set(end, Empty)
 
 	return &Grid{
 		data:   data,
-		Start:  Position{Location{startRow, startCol}, East},
-		End:    Position{Location{endRow, endCol}, North}, // end orientation does not matter
-		Width:  len(data[0]),
-		Height: len(data),
-
-		visited: make(map[Position]int),
-		counted: make(map[Location]bool),
+		Start:  Position{Location(start), geom.East},
+		End:    Position{Location(end), geom.North}, // end orientation does not matter
+		Width:  data.Width(),
+		Height: data.Height(),
 	}, nil
 }
 
-func parseRow(inRow string) ([]State, *int, *int, error) {
-	var startCol *int
-	var endCol *int
-
-	newRow := make([]State, len(inRow))
-	for idx, val := range inRow {
-		switch val {
-		case '\n':
-		case '#':
-			newRow[idx] = Wall
-		case '.':
-			newRow[idx] = Empty
-		case 'S':
-			newRow[idx] = Empty // set first so overwritten for offset = 0
-			tmp := idx
-			startCol = &tmp
-		case 'E':
-			newRow[idx] = Empty // set first so overwritten for offset = 0
-			tmp := idx
-			endCol = &tmp
-		default:
-			return nil, nil, nil, fmt.Errorf("%s %w", string(val), ErrUnknownInput)
-		}
+func parseCell(val rune) (State, error) {
+	switch val {
+	case '#':
+		return Wall, nil
+	case '.':
+		return Empty, nil
+	case 'S':
+		return Start, nil
+	case 'E':
+		return End, nil
+	default:
+		return Empty, fmt.Errorf("%s %w", string(val), ErrUnknownInput)
 	}
-	return newRow, startCol, endCol, nil
 }
@@ -6,40 +6,41 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/jstensland/advent-of-code/2024/day16"
+	"github.com/jstensland/advent-of-code/lib/geom"
 )
 
 func TestRight(t *testing.T) {
 	zeroLoc := day16.Location{0, 0}
-	assert.Equal(t, day16.Position{zeroLoc, day16.East}, day16.Position{zeroLoc, day16.North}.Right())
-	assert.Equal(t, day16.Position{zeroLoc, day16.South}, day16.Position{zeroLoc, day16.East}.Right())
-	assert.Equal(t, day16.Position{zeroLoc, day16.West}, day16.Position{zeroLoc, day16.South}.Right())
-	assert.Equal(t, day16.Position{zeroLoc, day16.North}, day16.Position{zeroLoc, day16.West}.Right())
+	assert.Equal(t, day16.Position{zeroLoc, geom.East}, day16.Position{zeroLoc, geom.North}.Right())
+	assert.Equal(t, day16.Position{zeroLoc, geom.South}, day16.Position{zeroLoc, geom.East}.Right())
+	assert.Equal(t, day16.Position{zeroLoc, geom.West}, day16.Position{zeroLoc, geom.South}.Right())
+	assert.Equal(t, day16.Position{zeroLoc, geom.North}, day16.Position{zeroLoc, geom.West}.Right())
 }
 
 func TestLeft(t *testing.T) {
 	zeroLoc := day16.Location{0, 0}
-	assert.Equal(t, day16.Position{zeroLoc, day16.West}, day16.Position{zeroLoc, day16.North}.Left())
-	assert.Equal(t, day16.Position{zeroLoc, day16.North}, day16.Position{zeroLoc, day16.East}.Left())
-	assert.Equal(t, day16.Position{zeroLoc, day16.East}, day16.Position{zeroLoc, day16.South}.Left())
-	assert.Equal(t, day16.Position{zeroLoc, day16.South}, day16.Position{zeroLoc, day16.West}.Left())
+	assert.Equal(t, day16.Position{zeroLoc, geom.West}, day16.Position{zeroLoc, geom.North}.Left())
+	assert.Equal(t, day16.Position{zeroLoc, geom.North}, day16.Position{zeroLoc, geom.East}.Left())
+	assert.Equal(t, day16.Position{zeroLoc, geom.East}, day16.Position{zeroLoc, geom.South}.Left())
+	assert.Equal(t, day16.Position{zeroLoc, geom.South}, day16.Position{zeroLoc, geom.West}.Left())
 }
 
 func TestRightForward(t *testing.T) {
 	startLoc := day16.Location{3, 3}
 	assert.Equal(t,
-		day16.Position{day16.Location{3, 4}, day16.East},
-		day16.Position{startLoc, day16.North}.Right().Forward(),
+		day16.Position{day16.Location{3, 4}, geom.East},
+		day16.Position{startLoc, geom.North}.Right().Forward(),
 	)
 	assert.Equal(t,
-		day16.Position{day16.Location{4, 3}, day16.South},
-		day16.Position{startLoc, day16.East}.Right().Forward(),
+		day16.Position{day16.Location{4, 3}, geom.South},
+		day16.Position{startLoc, geom.East}.Right().Forward(),
 	)
 	assert.Equal(t,
-		day16.Position{day16.Location{3, 2}, day16.West},
-		day16.Position{startLoc, day16.South}.Right().Forward(),
+		day16.Position{day16.Location{3, 2}, geom.West},
+		day16.Position{startLoc, geom.South}.Right().Forward(),
 	)
 	assert.Equal(t,
-		day16.Position{day16.Location{2, 3}, day16.North},
-		day16.Position{startLoc, day16.West}.Right().Forward(),
+		day16.Position{day16.Location{2, 3}, geom.North},
+		day16.Position{startLoc, geom.West}.Right().Forward(),
 	)
 }
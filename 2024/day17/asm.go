@@ -0,0 +1,155 @@
+package day17
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jstensland/advent-of-code/lib/parse"
+)
+
+// Errors from Assemble, each wrapped in a parse.LineError saying where it went wrong.
+var (
+	ErrUnknownMnemonic = errors.New("unknown mnemonic")
+	ErrOperand         = errors.New("bad operand")
+	ErrReservedCombo   = errors.New("combo operand 7 is reserved")
+	ErrLabel           = errors.New("bad label")
+)
+
+// maxOperand is the largest value that fits in the computer's 3 bits.
+const maxOperand = 7
+
+// labelPattern matches a label at the start of a line, e.g. "L0:" or "loop:".
+var labelPattern = regexp.MustCompile(`^([A-Za-z_]\w*):`) //nolint:gochecknoglobals // compiled once
+
+// Assemble turns mnemonic source, like the listing from Disassemble, into program data.
+//
+// Each line holds an optional label, an optional instruction and an optional comment starting
+// with ';'. Combo operands are 0-3 or a register A, B or C. Literal operands are 0-7, and jnz
+// can also take a label. bxc ignores its operand, so it may be left out and is then 0.
+//
+// Every bad line is reported, each wrapped in a parse.LineError.
+func Assemble(src io.Reader) ([]uint8, error) {
+	type pending struct {
+		line  int
+		idx   int // index of the operand in data
+		label string
+	}
+
+	var data []uint8
+	var errs []error
+	labels := map[string]int{}
+	var fixups []pending
+
+	scanner := bufio.NewScanner(src)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		text, _, _ := strings.Cut(scanner.Text(), ";")
+		text = strings.TrimSpace(text)
+
+		if m := labelPattern.FindStringSubmatch(text); m != nil {
+			if _, ok := labels[m[1]]; ok {
+				errs = append(errs, &parse.LineError{Line: lineNum, Err: fmt.Errorf("%w: %s defined twice", ErrLabel, m[1])})
+			}
+			labels[m[1]] = len(data)
+			text = strings.TrimSpace(text[len(m[0]):])
+		}
+		if text == "" {
+			continue
+		}
+
+		op, operand, label, err := assembleInstr(text)
+		if err != nil {
+			errs = append(errs, &parse.LineError{Line: lineNum, Err: err})
+			continue
+		}
+		if label != "" {
+			fixups = append(fixups, pending{line: lineNum, idx: len(data) + 1, label: label})
+		}
+		data = append(data, uint8(op), operand)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading source: %w", err)
+	}
+
+	for _, fix := range fixups {
+		addr, ok := labels[fix.label]
+		switch {
+		case !ok:
+			errs = append(errs, &parse.LineError{Line: fix.line, Err: fmt.Errorf("%w: %s is not defined", ErrLabel, fix.label)})
+		case addr > maxOperand:
+			errs = append(errs, &parse.LineError{
+				Line: fix.line,
+				Err:  fmt.Errorf("%w: %s is at %d, too far for a 3-bit jump", ErrLabel, fix.label, addr),
+			})
+		default:
+			data[fix.idx] = uint8(addr) //nolint:gosec // checked above
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return data, nil
+}
+
+// assembleInstr assembles one instruction. If the operand is a label, it is returned for the
+// caller to fill in once every label is known.
+func assembleInstr(text string) (OpCode, uint8, string, error) {
+	fields := strings.Fields(text)
+	op, ok := opcodes[strings.ToLower(fields[0])]
+	if !ok {
+		return 0, 0, "", fmt.Errorf("%w: %s", ErrUnknownMnemonic, fields[0])
+	}
+
+	switch {
+	case len(fields) == 1 && op == OpBxc:
+		return op, 0, "", nil
+	case len(fields) != 2: //nolint:mnd // mnemonic and operand
+		return 0, 0, "", fmt.Errorf("%w: %s takes one operand", ErrOperand, op.Mnemonic())
+	}
+	arg := fields[1]
+
+	if op.Combo() {
+		operand, err := comboOperand(arg)
+		return op, operand, "", err
+	}
+
+	val, err := strconv.Atoi(arg)
+	switch {
+	case err == nil && (val < 0 || val > maxOperand):
+		return 0, 0, "", fmt.Errorf("%w: %s literal %d is not 0-7", ErrOperand, op.Mnemonic(), val)
+	case err == nil:
+		return op, uint8(val), "", nil
+	case op == OpJnz && labelPattern.MatchString(arg+":"):
+		return op, 0, arg, nil
+	}
+	return 0, 0, "", fmt.Errorf("%w: %s takes a literal, not %s", ErrOperand, op.Mnemonic(), arg)
+}
+
+// comboOperand reads a combo operand, a literal 0-3 or a register.
+func comboOperand(arg string) (uint8, error) {
+	switch strings.ToUpper(arg) {
+	case "0", "1", "2", "3":
+		return arg[0] - '0', nil
+	case "A", "B", "C":
+		return strings.ToUpper(arg)[0] - 'A' + 4, nil //nolint:mnd // A is 4
+	case "7":
+		return 0, ErrReservedCombo
+	}
+	return 0, fmt.Errorf("%w: combo operand must be 0-3, A, B or C, not %s", ErrOperand, arg)
+}
+
+// opcodes maps each mnemonic to its opcode.
+//
+//nolint:gochecknoglobals // built once rather than for every instruction
+var opcodes = func() map[string]OpCode {
+	out := map[string]OpCode{}
+	for op := OpAdv; op <= OpCdv; op++ {
+		out[op.Mnemonic()] = op
+	}
+	return out
+}()
@@ -0,0 +1,108 @@
+package day17_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/2024/day17"
+	"github.com/jstensland/advent-of-code/2024/input"
+	"github.com/jstensland/advent-of-code/lib/parse"
+)
+
+func TestAssemble(t *testing.T) {
+	src := `; the part 2 example
+start:
+    adv 1        ; a >>= 1
+    out a
+loop: jnz start
+    BXC
+`
+
+	data, err := day17.Assemble(strings.NewReader(src))
+
+	require.NoError(t, err)
+	assert.Equal(t, []uint8{0, 1, 5, 4, 3, 0, 4, 0}, data)
+}
+
+func TestAssemble_RoundTrip(t *testing.T) {
+	computer, err := day17.ParseIn(input.Reader("./input.txt"))
+	require.NoError(t, err)
+
+	programs := map[string][]uint8{
+		"example":                {0, 1, 5, 4, 3, 0},
+		"instruction 1":          {2, 6},
+		"instruction 2":          {5, 0, 5, 1, 5, 4},
+		"instruction 4":          {1, 7},
+		"instruction 5":          {4, 0},
+		"part 2 example":         {0, 3, 5, 4, 3, 0},
+		"input":                  computer.GetData(),
+		"forward jump":           {3, 4, 5, 0, 5, 1},
+		"nested loops":           {0, 1, 5, 4, 3, 2, 3, 0},
+		"ignored bxc operand":    {4, 7, 5, 5},
+		"jump to the last instr": {3, 6, 1, 1, 5, 6, 0, 2},
+	}
+	for desc, data := range programs {
+		t.Run(desc, func(t *testing.T) {
+			src := day17.NewProgram(data).Disassemble()
+
+			got, err := day17.Assemble(strings.NewReader(src))
+
+			require.NoError(t, err, src)
+			assert.Equal(t, data, got, src)
+		})
+	}
+}
+
+func TestAssemble_Errors(t *testing.T) {
+	testCases := []struct {
+		desc string
+		src  string
+		line int
+		want error
+	}{
+		{desc: "unknown mnemonic", src: "out A\nmov A", line: 2, want: day17.ErrUnknownMnemonic},
+		{desc: "reserved combo", src: "\n\nbst 7", line: 3, want: day17.ErrReservedCombo},
+		{desc: "register for a literal", src: "bxl A", line: 1, want: day17.ErrOperand},
+		{desc: "combo 4 is written as A", src: "adv 4", line: 1, want: day17.ErrOperand},
+		{desc: "literal too big", src: "bxl 8", line: 1, want: day17.ErrOperand},
+		{desc: "missing operand", src: "out", line: 1, want: day17.ErrOperand},
+		{desc: "extra operand", src: "out A B", line: 1, want: day17.ErrOperand},
+		{desc: "undefined label", src: "out A\njnz loop", line: 2, want: day17.ErrLabel},
+		{desc: "duplicate label", src: "x: out A\nx: out B", line: 2, want: day17.ErrLabel},
+		{
+			desc: "label out of reach",
+			src:  "out 0\nout 0\nout 0\nout 0\nfar: out 1\njnz far",
+			line: 6,
+			want: day17.ErrLabel,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := day17.Assemble(strings.NewReader(tC.src))
+
+			require.ErrorIs(t, err, tC.want)
+			var lineErr *parse.LineError
+			require.ErrorAs(t, err, &lineErr)
+			assert.Equal(t, tC.line, lineErr.Line)
+		})
+	}
+}
+
+func TestAssemble_ReportsEveryLine(t *testing.T) {
+	_, err := day17.Assemble(strings.NewReader("bst 7\nout A\nbxl C\n"))
+
+	require.Error(t, err)
+	var lines []int
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() { //nolint:errorlint // errors.Join
+		var lineErr *parse.LineError
+		if errors.As(e, &lineErr) {
+			lines = append(lines, lineErr.Line)
+		}
+	}
+	assert.Equal(t, []int{1, 3}, lines)
+	assert.Contains(t, err.Error(), "line 3: bad operand: bxl takes a literal, not C")
+}
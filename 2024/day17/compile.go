@@ -0,0 +1,157 @@
+package day17
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// operand sources for a compiled instruction
+const (
+	srcLiteral uint8 = iota
+	srcA
+	srcB
+	srcC
+	srcReserved
+	srcInvalid
+)
+
+// compiledInstr is an instruction with its operand already decoded.
+type compiledInstr struct {
+	code OpCode
+	src  uint8 // where the operand's value comes from
+	lit  int   // the operand's value when src is srcLiteral
+}
+
+// Compiled is a program with every instruction decoded up front, run by a loop that keeps the
+// registers in local variables and writes output to an int slice rather than a string. It
+// runs the same as RunProgram.
+type Compiled struct {
+	instrs []compiledInstr // indexed by address, as a jnz can land anywhere
+}
+
+// Compile decodes an instruction for each address one could start at.
+func (p *Program) Compile() *Compiled {
+	instrs := make([]compiledInstr, max(len(p.data)-1, 0))
+	for addr := range instrs {
+		code, operand := OpCode(p.data[addr]), p.data[addr+1]
+		in := compiledInstr{code: code, lit: int(operand)}
+		if code.Combo() {
+			in.src = comboSource(operand)
+		}
+		instrs[addr] = in
+	}
+	return &Compiled{instrs: instrs}
+}
+
+func comboSource(operand uint8) uint8 {
+	switch operand {
+	case 0, 1, 2, 3:
+		return srcLiteral
+	case 4: //nolint:mnd // register A
+		return srcA
+	case 5: //nolint:mnd // register B
+		return srcB
+	case 6: //nolint:mnd // register C
+		return srcC
+	case reservedCombo:
+		return srcReserved
+	}
+	return srcInvalid
+}
+
+// Run runs the program from the start with the registers set, appending each output to out,
+// and returns out. Pass the last out back in, cut to out[:0], to save allocating on every
+// run.
+func (cp *Compiled) Run(regA, regB, regC int, out []int) []int {
+	out, _ = cp.exec(regA, regB, regC, out, nil, false)
+	return out
+}
+
+// Outputs reports whether the program outputs exactly want when run with the registers set.
+// It stops at the first wrong output, which makes it cheap to rule out values in a search.
+func (cp *Compiled) Outputs(regA, regB, regC int, want []uint8) bool {
+	_, ok := cp.exec(regA, regB, regC, nil, want, true)
+	return ok
+}
+
+// exec runs the program. If check is set, it compares each output with want instead of
+// keeping it, stopping at the first that differs.
+//
+// The registers live in an array indexed by operand source, with the literal in slot 0, so
+// reading a combo operand is a lookup rather than a branch.
+//
+//nolint:mnd // rules are magic
+func (cp *Compiled) exec(a, b, c int, out []int, want []uint8, check bool) ([]int, bool) {
+	regs := [...]int{srcLiteral: 0, srcA: a, srcB: b, srcC: c}
+	checked := 0
+	for ip := 0; ip < len(cp.instrs); {
+		in := &cp.instrs[ip]
+		ip += 2
+
+		regs[srcLiteral] = in.lit
+		if in.src > srcC {
+			panic(operandPanic(in.src))
+		}
+		x := regs[in.src]
+
+		switch in.code {
+		case OpAdv:
+			regs[srcA] = div(regs[srcA], x)
+		case OpBxl:
+			regs[srcB] ^= x
+		case OpBst:
+			regs[srcB] = x % 8
+		case OpJnz:
+			if regs[srcA] != 0 {
+				ip = x
+			}
+		case OpBxc:
+			regs[srcB] ^= regs[srcC]
+		case OpOut:
+			if !check {
+				out = append(out, x%8)
+				continue
+			}
+			if checked == len(want) || x%8 != int(want[checked]) {
+				return out, false
+			}
+			checked++
+		case OpBdv:
+			regs[srcB] = div(regs[srcA], x)
+		case OpCdv:
+			regs[srcC] = div(regs[srcA], x)
+		default:
+			panic(fmt.Sprintf("unhandled opcode %d", in.code))
+		}
+	}
+	return out, checked == len(want)
+}
+
+// operandPanic is the same panic the combo operand gets in RunProgram.
+func operandPanic(src uint8) string {
+	if src == srcReserved {
+		return "reserved and should not appear"
+	}
+	return "operand greater than 7 should be impossible"
+}
+
+// div is a / 2^x as the instructions work it out, but with a shift when that gives the same
+// answer, which is much quicker.
+//
+//nolint:gosec,mnd // shifts as in the instructions themselves
+func div(a, x int) int {
+	if a >= 0 && x >= 0 && x < 64 {
+		return a >> uint(x)
+	}
+	return a / (1 << uint(x))
+}
+
+// FormatOutput joins the output with commas, the same as Result.
+func FormatOutput(out []int) string {
+	vals := make([]string, len(out))
+	for idx, val := range out {
+		vals[idx] = strconv.Itoa(val)
+	}
+	return strings.Join(vals, ",")
+}
@@ -0,0 +1,120 @@
+package day17_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/2024/day17"
+	"github.com/jstensland/advent-of-code/2024/input"
+)
+
+func inputComputer(tb testing.TB) *day17.Computer {
+	tb.Helper()
+	computer, err := day17.ParseIn(input.Reader("./input.txt"))
+	require.NoError(tb, err)
+	return computer
+}
+
+func TestCompiled_MatchesRunProgram(t *testing.T) {
+	programs := map[string][]uint8{
+		"input":              inputComputer(t).GetData(),
+		"example":            {0, 1, 5, 4, 3, 0},
+		"part 2 example":     {0, 3, 5, 4, 3, 0},
+		"all the registers":  {2, 4, 7, 5, 6, 1, 1, 7, 4, 0, 5, 5, 5, 6, 0, 2, 3, 0},
+		"jump between instr": {0, 1, 3, 3, 5, 4, 5, 5},
+		"odd length":         {5, 4, 0},
+	}
+	regAs := []int{0, 1, 7, 8, 729, 2024, 117440, 64584136, 37221334433268}
+	for a := range 2000 {
+		regAs = append(regAs, a*7919)
+	}
+
+	for desc, data := range programs {
+		t.Run(desc, func(t *testing.T) {
+			compiled := day17.NewProgram(data).Compile()
+			var out []int
+			for _, regA := range regAs {
+				computer := day17.NewComputer(regA, 3, 5, data)
+				want := computer.RunProgram()
+
+				out = compiled.Run(regA, 3, 5, out[:0])
+
+				require.Equal(t, want, day17.FormatOutput(out), "A=%d", regA)
+				assert.True(t, compiled.Outputs(regA, 3, 5, parseOut(out)), "A=%d", regA)
+			}
+		})
+	}
+}
+
+func parseOut(out []int) []uint8 {
+	vals := make([]uint8, len(out))
+	for idx, val := range out {
+		vals[idx] = uint8(val) //nolint:gosec // outputs are 0-7
+	}
+	return vals
+}
+
+func TestCompiled_Outputs(t *testing.T) {
+	compiled := day17.NewProgram([]uint8{0, 1, 5, 4, 3, 0}).Compile()
+
+	assert.True(t, compiled.Outputs(729, 0, 0, []uint8{4, 6, 3, 5, 6, 3, 5, 2, 1, 0}))
+	assert.False(t, compiled.Outputs(729, 0, 0, []uint8{4, 6, 3}), "too short")
+	assert.False(t, compiled.Outputs(729, 0, 0, []uint8{4, 6, 3, 5, 6, 3, 5, 2, 1, 0, 0}), "too long")
+	assert.False(t, compiled.Outputs(729, 0, 0, []uint8{4, 7}))
+	assert.True(t, compiled.Outputs(0, 0, 0, []uint8{0}))
+}
+
+func TestCompiled_ShiftEdges(t *testing.T) {
+	// bdv B then cdv C, with the shift coming from the registers
+	data := []uint8{6, 5, 7, 6, 5, 5, 5, 6}
+	compiled := day17.NewProgram(data).Compile()
+
+	for _, shift := range []int{0, 1, 62, 63} {
+		for _, regA := range []int{0, 5, 1 << 62, -9} {
+			want := day17.NewComputer(regA, shift, shift, data).RunProgram()
+			assert.Equal(t, want, day17.FormatOutput(compiled.Run(regA, shift, shift, nil)), "A=%d shift=%d", regA, shift)
+		}
+	}
+	assert.Panics(t, func() { compiled.Run(1, 64, 64, nil) }, "divides by zero, as RunProgram does")
+}
+
+func TestCompiled_ReservedCombo(t *testing.T) {
+	compiled := day17.NewProgram([]uint8{5, 7}).Compile()
+
+	assert.Panics(t, func() { compiled.Run(0, 0, 0, nil) })
+	assert.Empty(t, day17.NewProgram(nil).Compile().Run(1, 2, 3, nil), "nothing to run")
+}
+
+// BenchmarkRunMany runs the input program over a range of A, as a search would.
+func BenchmarkRunMany(b *testing.B) {
+	computer := inputComputer(b)
+	data := computer.GetData()
+	const runs = 10_000
+
+	b.Run("RunProgram", func(b *testing.B) {
+		for b.Loop() {
+			for a := range runs {
+				day17.NewComputer(a<<30, 0, 0, data).RunProgram()
+			}
+		}
+	})
+	b.Run("Compiled", func(b *testing.B) {
+		compiled := computer.Program.Compile()
+		var out []int
+		for b.Loop() {
+			for a := range runs {
+				out = compiled.Run(a<<30, 0, 0, out[:0])
+			}
+		}
+	})
+	b.Run("Compiled.Outputs", func(b *testing.B) {
+		compiled := computer.Program.Compile()
+		for b.Loop() {
+			for a := range runs {
+				compiled.Outputs(a<<30, 0, 0, data)
+			}
+		}
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/jstensland/advent-of-code/2024/day17"
+	"github.com/jstensland/advent-of-code/2024/input"
 )
 
 func example() io.Reader {
@@ -141,58 +142,61 @@ Register C: 0
 Program: 0,3,5,4,3,0`)
 }
 
-// TOO SLOW
-
-// func TestSolvePart2Example(t *testing.T) {
-// 	// sanity checks
-// 	computer, err := day17.ParseIn(Part2Example())
-// 	require.NoError(t, err)
-// 	assert.Equal(t, []uint8{0, 3, 5, 4, 3, 0}, computer.GetData())
-// 	assert.Equal(t, "0,3,5,4,3,0", computer.Program.DataString())
-//
-// 	out, err := day17.SolvePart2BruteForce(Part2Example())
-// 	require.NoError(t, err)
-//
-// 	// Answer
-// 	assert.Equal(t, 117440, out)
-// }
-
-// TOO SLOW
-
-// func TestSolvePart2(t *testing.T) {
-// 	inFile := "./input.txt"
-// 	in, err := os.Open(inFile)
-// 	require.NoError(t, err)
-//
-// 	out, err := day17.SolvePart2BruteForce(in)
-//
-// 	require.NoError(t, err)
-// 	assert.Equal(t, "?", out)
-// }
-
-// TOO SLOW
-
-// func TestSolvePart2Example_3(t *testing.T) {
-// 	// sanity checks
-// 	computer, err := day17.ParseIn(Part2Example())
-// 	require.NoError(t, err)
-// 	assert.Equal(t, []uint8{0, 3, 5, 4, 3, 0}, computer.GetData())
-// 	assert.Equal(t, "0,3,5,4,3,0", computer.Program.DataString())
-//
-// 	out, err := day17.SolvePart2Dynamic(Part2Example())
-// 	require.NoError(t, err)
-//
-// 	// Answer
-// 	assert.Equal(t, 117440, out)
-// }
-
-// func TestSolvePart2_3(t *testing.T) {
-// 	inFile := "./input.txt"
-// 	in, err := os.Open(inFile)
-// 	require.NoError(t, err)
-//
-// 	out, err := day17.SolvePart2BruteForce(in) // TOO SLOW
-//
-// 	require.NoError(t, err)
-// 	assert.Equal(t, "?", out)
-// }
+func TestSolvePart2Example(t *testing.T) {
+	out, err := day17.SolvePart2(Part2Example())
+
+	require.NoError(t, err)
+	assert.Equal(t, 117440, out)
+}
+
+func TestSolvePart2(t *testing.T) {
+	out, err := day17.SolvePart2(input.Reader("./input.txt"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 37221334433268, out) // outputs itself, see TestFindQuine_IsAQuine
+}
+
+func TestFindQuine_IsAQuine(t *testing.T) {
+	for desc, in := range map[string]io.Reader{"example": Part2Example(), "input": input.Reader("./input.txt")} {
+		t.Run(desc, func(t *testing.T) {
+			computer, err := day17.ParseIn(in)
+			require.NoError(t, err)
+			regA, err := computer.FindQuine()
+			require.NoError(t, err)
+
+			computer.SetRegisterA(regA)
+			assert.Equal(t, computer.Program.DataString(), computer.RunProgram())
+
+			computer.Reset()
+			computer.SetRegisterA(regA - 1)
+			assert.NotEqual(t, computer.Program.DataString(), computer.RunProgram(), "not the lowest")
+		})
+	}
+}
+
+func TestFindQuine_NotAShiftLoop(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data []uint8
+	}{
+		{desc: "no loop", data: []uint8{0, 3, 5, 4}},
+		{desc: "shifts by 1", data: []uint8{0, 1, 5, 4, 3, 0}},
+		{desc: "shifts twice", data: []uint8{0, 3, 0, 3, 5, 4, 3, 0}},
+		{desc: "loops to the middle", data: []uint8{5, 4, 0, 3, 3, 2}},
+		{desc: "empty", data: nil},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := day17.NewComputer(0, 0, 0, tC.data).FindQuine()
+
+			require.ErrorIs(t, err, day17.ErrNotShiftLoop)
+		})
+	}
+}
+
+func TestFindQuine_NoQuine(t *testing.T) {
+	// out 1 every time round can't copy a program with other digits in it
+	_, err := day17.NewComputer(0, 0, 0, []uint8{5, 1, 0, 3, 3, 0}).FindQuine()
+
+	require.ErrorIs(t, err, day17.ErrNoQuine)
+}
@@ -0,0 +1,218 @@
+package day17
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// State is everything that changes as a program runs, so it can be inspected and restored.
+type State struct {
+	IP   int // instruction pointer
+	A    int
+	B    int
+	C    int
+	Out  string // output so far, as from Result
+	Vals []int  // output so far, as values
+}
+
+func (s State) String() string {
+	return fmt.Sprintf("ip=%d A=%d B=%d C=%d out=%s", s.IP, s.A, s.B, s.C, s.Out)
+}
+
+// State is the computer's state now.
+func (c *Computer) State() State {
+	return State{
+		IP: c.Program.instructionIdx, A: c.registerA, B: c.registerB, C: c.registerC,
+		Out: c.Result(), Vals: slices.Clone(c.outVals),
+	}
+}
+
+// Restore puts the computer back in an earlier state.
+func (c *Computer) Restore(s State) {
+	c.Program.instructionIdx = s.IP
+	c.registerA, c.registerB, c.registerC = s.A, s.B, s.C
+	c.out = ""
+	if s.Out != "" {
+		c.out = s.Out + ","
+	}
+	c.outVals = slices.Clone(s.Vals)
+	c.newOut = false
+}
+
+// StopReason is why Run stopped.
+type StopReason int
+
+const (
+	StopHalted     StopReason = iota // the program ran off the end
+	StopBreakpoint                   // reached an instruction with a breakpoint
+	StopCondition                    // a register condition became true
+	StopWatch                        // an output matched a watchpoint
+	StopUntil                        // the until function passed to Run returned true
+)
+
+func (r StopReason) String() string {
+	return [...]string{"halted", "breakpoint", "condition", "watchpoint", "until"}[r]
+}
+
+// Stop says why and where Run stopped.
+type Stop struct {
+	Reason StopReason
+	Name   string // which breakpoint, condition or watchpoint, if any
+	State  State
+}
+
+func (s Stop) String() string {
+	if s.Name == "" {
+		return fmt.Sprintf("%v: %v", s.Reason, s.State)
+	}
+	return fmt.Sprintf("%v %s: %v", s.Reason, s.Name, s.State)
+}
+
+// TraceEntry is one executed instruction and the state before it ran.
+type TraceEntry struct {
+	Before State
+	Instr  Instr
+}
+
+type condition struct {
+	name string
+	fn   func(State) bool
+}
+
+type watchpoint struct {
+	name string
+	fn   func(val int) bool
+}
+
+// Debugger runs a Computer an instruction at a time, stopping at breakpoints, and keeps a
+// trace of every instruction so it can rewind.
+type Debugger struct {
+	c           *Computer
+	breakpoints map[int]bool
+	conditions  []condition
+	watches     []watchpoint
+	trace       []TraceEntry
+}
+
+// NewDebugger returns a Debugger for the computer, starting from its current state.
+func NewDebugger(c *Computer) *Debugger {
+	return &Debugger{c: c, breakpoints: map[int]bool{}}
+}
+
+// State is the computer's state now.
+func (d *Debugger) State() State {
+	return d.c.State()
+}
+
+// Break stops Run before the instruction at ip.
+func (d *Debugger) Break(ip int) {
+	d.breakpoints[ip] = true
+}
+
+// ClearBreak removes the breakpoint at ip.
+func (d *Debugger) ClearBreak(ip int) {
+	delete(d.breakpoints, ip)
+}
+
+// BreakWhen stops Run when fn becomes true, false before an instruction and true after it, e.g.
+// when a register reaches a value.
+func (d *Debugger) BreakWhen(name string, fn func(State) bool) {
+	d.conditions = append(d.conditions, condition{name: name, fn: fn})
+}
+
+// Watch stops Run after an out instruction writes a value fn accepts. A nil fn stops on every
+// output.
+func (d *Debugger) Watch(name string, fn func(val int) bool) {
+	if fn == nil {
+		fn = func(int) bool { return true }
+	}
+	d.watches = append(d.watches, watchpoint{name: name, fn: fn})
+}
+
+// Trace is every instruction run so far, oldest first.
+func (d *Debugger) Trace() []TraceEntry {
+	return d.trace
+}
+
+// Step runs the next instruction, recording it in the trace. It returns false once the
+// program has halted.
+func (d *Debugger) Step() bool {
+	_, ok := d.step()
+	return ok
+}
+
+// step runs the next instruction and returns the state before it.
+func (d *Debugger) step() (State, bool) {
+	if d.c.Halted() {
+		return State{}, false
+	}
+	before := d.c.State()
+	in := Instr{Addr: before.IP, Op: OpCode(d.c.Program.data[before.IP]), Operand: d.c.Program.data[before.IP+1]}
+	d.c.Step()
+	d.trace = append(d.trace, TraceEntry{Before: before, Instr: in})
+	return before, true
+}
+
+// Run steps until the program halts, a breakpoint, condition or watchpoint is hit, or until
+// is true of the state after an instruction. until may be nil. It always runs at least one
+// instruction, so calling it again carries on past a breakpoint.
+func (d *Debugger) Run(until func(State) bool) Stop {
+	for {
+		before, ok := d.step()
+		state := d.c.State()
+		if !ok {
+			return Stop{Reason: StopHalted, State: state}
+		}
+		if len(state.Vals) > len(before.Vals) {
+			val := state.Vals[len(state.Vals)-1]
+			for _, w := range d.watches {
+				if w.fn(val) {
+					return Stop{Reason: StopWatch, Name: w.name, State: state}
+				}
+			}
+		}
+		for _, cond := range d.conditions {
+			if !cond.fn(before) && cond.fn(state) {
+				return Stop{Reason: StopCondition, Name: cond.name, State: state}
+			}
+		}
+		if until != nil && until(state) {
+			return Stop{Reason: StopUntil, State: state}
+		}
+		if d.breakpoints[state.IP] {
+			return Stop{Reason: StopBreakpoint, Name: "ip " + strconv.Itoa(state.IP), State: state}
+		}
+	}
+}
+
+// Rewind undoes the last n instructions, or as many as there are. It returns how many it
+// undid.
+func (d *Debugger) Rewind(n int) int {
+	n = min(n, len(d.trace))
+	if n <= 0 {
+		return 0
+	}
+	d.c.Restore(d.trace[len(d.trace)-n].Before)
+	d.trace = d.trace[:len(d.trace)-n]
+	return n
+}
+
+// Listing is the disassembly with the next instruction marked and breakpoints flagged.
+func (d *Debugger) Listing() string {
+	var sb strings.Builder
+	ip := d.c.Program.instructionIdx
+	for _, in := range d.c.Program.Instructions() {
+		mark := "  "
+		if in.Addr == ip {
+			mark = "=>"
+		}
+		brk := " "
+		if d.breakpoints[in.Addr] {
+			brk = "*"
+		}
+		fmt.Fprintf(&sb, "%s%s %2d  %v\n", mark, brk, in.Addr, in)
+	}
+	return sb.String()
+}
@@ -0,0 +1,144 @@
+package day17_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/2024/day17"
+)
+
+func exampleDebugger(t *testing.T) *day17.Debugger {
+	t.Helper()
+	computer, err := day17.ParseIn(example())
+	require.NoError(t, err)
+	return day17.NewDebugger(computer)
+}
+
+func TestDebugger_StepAndHalt(t *testing.T) {
+	d := exampleDebugger(t)
+
+	require.True(t, d.Step())
+	assert.Equal(t, day17.State{IP: 2, A: 364}, d.State())
+
+	stop := d.Run(nil)
+
+	assert.Equal(t, day17.StopHalted, stop.Reason)
+	assert.Equal(t, "4,6,3,5,6,3,5,2,1,0", stop.State.Out)
+	assert.False(t, d.Step(), "nothing left to run")
+	assert.Len(t, d.Trace(), 30, "10 loops of 3 instructions")
+}
+
+func TestDebugger_Breakpoint(t *testing.T) {
+	d := exampleDebugger(t)
+	d.Break(4)
+
+	stop := d.Run(nil)
+	assert.Equal(t, day17.StopBreakpoint, stop.Reason)
+	assert.Equal(t, "ip 4", stop.Name)
+	assert.Equal(t, "4", stop.State.Out)
+
+	stop = d.Run(nil)
+	assert.Equal(t, day17.StopBreakpoint, stop.Reason, "carries on to the next time round")
+	assert.Equal(t, "4,6", stop.State.Out)
+
+	d.ClearBreak(4)
+	assert.Equal(t, day17.StopHalted, d.Run(nil).Reason)
+}
+
+func TestDebugger_ConditionWatchAndUntil(t *testing.T) {
+	d := exampleDebugger(t)
+	d.Watch("a 5", func(val int) bool { return val == 5 })
+	d.BreakWhen("A < 10", func(s day17.State) bool { return s.A < 10 })
+
+	stop := d.Run(nil)
+	assert.Equal(t, day17.StopWatch, stop.Reason)
+	assert.Equal(t, "4,6,3,5", stop.State.Out)
+
+	stop = d.Run(nil)
+	assert.Equal(t, day17.StopCondition, stop.Reason)
+	assert.Equal(t, "A < 10", stop.Name)
+	assert.Equal(t, day17.State{IP: 2, A: 5, Out: "4,6,3,5,6,3", Vals: []int{4, 6, 3, 5, 6, 3}}, stop.State)
+
+	stop = d.Run(nil)
+	assert.Equal(t, day17.StopWatch, stop.Reason, "the condition stays true, so doesn't stop again")
+	assert.Equal(t, "4,6,3,5,6,3,5", stop.State.Out)
+
+	stop = d.Run(func(s day17.State) bool { return strings.HasSuffix(s.Out, "1") })
+	assert.Equal(t, day17.StopUntil, stop.Reason)
+	assert.Equal(t, "4,6,3,5,6,3,5,2,1", stop.State.Out)
+}
+
+func TestDebugger_Rewind(t *testing.T) {
+	d := exampleDebugger(t)
+	start := d.State()
+	for range 7 {
+		d.Step()
+	}
+	mid := d.State()
+	d.Run(nil)
+
+	assert.Equal(t, 23, d.Rewind(23))
+	assert.Equal(t, mid, d.State())
+	assert.Len(t, d.Trace(), 7)
+
+	assert.Equal(t, 7, d.Rewind(100), "only as far as the start")
+	assert.Equal(t, start, d.State())
+	assert.Equal(t, 0, d.Rewind(1))
+
+	assert.Equal(t, "4,6,3,5,6,3,5,2,1,0", d.Run(nil).State.Out, "runs the same again")
+}
+
+func TestDebugger_REPL(t *testing.T) {
+	d := exampleDebugger(t)
+	cmds := strings.Join([]string{
+		"step 2",
+		"break 4",
+		"run",
+		"when a == 0",
+		"watch 3",
+		"c",
+		"back 2",
+		"trace 1",
+		"step 0",
+		"nope",
+		"deps 6",
+		"quit",
+		"regs", // never read
+	}, "\n")
+	var out bytes.Buffer
+
+	require.NoError(t, d.REPL(strings.NewReader(cmds), &out))
+
+	lines := strings.Split(out.String(), "(day17) ")
+	assert.Equal(t, "ip=4 A=364 B=0 C=0 out=4\n", lines[1])
+	assert.Equal(t, "breakpoint ip 4: ip=4 A=182 B=0 C=0 out=4,6\n", lines[3])
+	assert.Equal(t, "watchpoint output 3: ip=4 A=91 B=0 C=0 out=4,6,3\n", lines[6])
+	assert.Equal(t, "rewound 2\nip=0 A=182 B=0 C=0 out=4,6\n", lines[7])
+	assert.Equal(t, "jnz 0    from ip=4 A=182 B=0 C=0 out=4,6\n", lines[8])
+	assert.Equal(t, "error: bad command: 0 is not a positive count\n", lines[9])
+	assert.Equal(t, "error: bad command: nope, try help\n", lines[10])
+	deps := strings.Split(lines[11], "\n")
+	require.Len(t, deps, 7, "an output per bit of A, then the trailing newline")
+	assert.Equal(t, "loop 0: A bits 1-3: ((A >> 1) % 8)", deps[0])
+	assert.Equal(t, "loop 4: A bits 5: ((A >> 5) % 8)", deps[4])
+	assert.Len(t, lines, 13)
+}
+
+func TestDebugger_WatchNegativeOutput(t *testing.T) {
+	// out A, with A negative, writes more than one character
+	d := day17.NewDebugger(day17.NewComputer(-3, 0, 0, []uint8{5, 4, 5, 4}))
+	d.Watch("-3", func(val int) bool { return val == -3 })
+
+	stop := d.Run(nil)
+	assert.Equal(t, day17.StopWatch, stop.Reason)
+	assert.Equal(t, "-3", stop.State.Out)
+	assert.Equal(t, []int{-3}, stop.State.Vals)
+
+	d.Rewind(1)
+	assert.Empty(t, d.State().Vals)
+	assert.Equal(t, day17.StopWatch, d.Run(nil).Reason, "the same output again after rewinding")
+}
@@ -0,0 +1,237 @@
+package day17
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The opcodes of the 3-bit computer, in the order of the puzzle.
+const (
+	OpAdv OpCode = iota
+	OpBxl
+	OpBst
+	OpJnz
+	OpBxc
+	OpOut
+	OpBdv
+	OpCdv
+)
+
+// reservedCombo is the combo operand that should never appear in a valid program.
+const reservedCombo = 7
+
+// Mnemonic is the lower case name of the opcode, as in the puzzle.
+func (op OpCode) Mnemonic() string {
+	names := [...]string{"adv", "bxl", "bst", "jnz", "bxc", "out", "bdv", "cdv"}
+	if int(op) >= len(names) {
+		return fmt.Sprintf("op%d", op)
+	}
+	return names[op]
+}
+
+// Combo reports whether the opcode reads its operand as a combo operand, which can name a
+// register, rather than as a literal.
+func (op OpCode) Combo() bool {
+	switch op {
+	case OpAdv, OpBst, OpOut, OpBdv, OpCdv:
+		return true
+	case OpBxl, OpJnz, OpBxc:
+	}
+	return false
+}
+
+// Instr is one decoded instruction.
+type Instr struct {
+	Addr    int // index of the opcode in the program data
+	Op      OpCode
+	Operand uint8
+}
+
+// operand is how the listing writes the operand: registers by name and literals as numbers.
+// bxc ignores its operand, so it is only written when it isn't 0, to keep the data the same
+// through Assemble.
+func (in Instr) operand() string {
+	switch {
+	case in.Op == OpBxc && in.Operand == 0:
+		return ""
+	case in.Op.Combo() && in.Operand >= 4 && in.Operand < reservedCombo: //nolint:mnd // 4-6 are registers
+		return string(rune('A' + in.Operand - 4)) //nolint:mnd // A is 4
+	default:
+		return strconv.Itoa(int(in.Operand))
+	}
+}
+
+// String is the instruction as a line of assembly, e.g. "bst A" or "jnz 0".
+func (in Instr) String() string {
+	if op := in.operand(); op != "" {
+		return in.Op.Mnemonic() + " " + op
+	}
+	return in.Op.Mnemonic()
+}
+
+// NewProgram returns a program holding data, ready to run from the start.
+func NewProgram(data []uint8) *Program {
+	return &Program{data: data}
+}
+
+// Instructions decodes the program two values at a time from the start. A trailing opcode
+// without an operand is left out, since the computer halts trying to read it.
+//
+// IMPROVEMENT: a jnz to an odd address reads the program out of step. None of the puzzle
+// programs do that, so it's not decoded here.
+func (p *Program) Instructions() []Instr {
+	instrs := make([]Instr, 0, len(p.data)/2)
+	for addr := 0; addr+1 < len(p.data); addr += 2 {
+		instrs = append(instrs, Instr{Addr: addr, Op: OpCode(p.data[addr]), Operand: p.data[addr+1]})
+	}
+	return instrs
+}
+
+// jumpTargets are the addresses any jnz can jump to.
+func jumpTargets(instrs []Instr) map[int]bool {
+	targets := map[int]bool{}
+	for _, in := range instrs {
+		if in.Op == OpJnz {
+			targets[int(in.Operand)] = true
+		}
+	}
+	return targets
+}
+
+// Disassemble lists the program one instruction per line, with a label line before each jump
+// target and the address and raw values of each instruction in a comment.
+//
+//	L0:
+//	    bst A        ; 0: 2,4
+//	    ...
+//	    jnz 0        ; 14: 3,0
+func (p *Program) Disassemble() string {
+	instrs := p.Instructions()
+	targets := jumpTargets(instrs)
+
+	var sb strings.Builder
+	for _, in := range instrs {
+		if targets[in.Addr] {
+			fmt.Fprintf(&sb, "L%d:\n", in.Addr)
+		}
+		note := ""
+		switch {
+		case in.Op.Combo() && in.Operand == reservedCombo:
+			note = " reserved combo operand"
+		case in.Op == OpJnz && !targetsInstr(instrs, int(in.Operand)):
+			note = " jumps off the program or between instructions"
+		}
+		fmt.Fprintf(&sb, "    %-12s ; %d: %d,%d%s\n", in, in.Addr, in.Op, in.Operand, note)
+	}
+	if len(p.data)%2 == 1 {
+		fmt.Fprintf(&sb, "    ; %d: %d without an operand halts\n", len(p.data)-1, p.data[len(p.data)-1])
+	}
+	return sb.String()
+}
+
+func targetsInstr(instrs []Instr, addr int) bool {
+	return addr%2 == 0 && addr/2 < len(instrs)
+}
+
+// PseudoGo renders the program as Go-like code over registers a, b and c. A jnz back to an
+// earlier instruction becomes a loop around the instructions it repeats, which is how every
+// puzzle program is built. Any other jump is left as a goto.
+func (p *Program) PseudoGo() string {
+	instrs := p.Instructions()
+	loops := map[int][]int{} // start index to the end index of each loop from there, inclusive
+	closes := map[int]bool{} // end indexes of loops
+	gotos := map[int]bool{}
+	for idx, in := range instrs {
+		if in.Op != OpJnz {
+			continue
+		}
+		start := int(in.Operand) / 2
+		if !targetsInstr(instrs, int(in.Operand)) || start > idx || jumpsInto(instrs, start, idx) {
+			gotos[int(in.Operand)] = true
+			continue
+		}
+		loops[start] = append(loops[start], idx)
+		closes[idx] = true
+	}
+
+	var sb strings.Builder
+	depth := 0
+	write := func(code string) {
+		for line := range strings.SplitSeq(code, "\n") {
+			sb.WriteString(strings.Repeat("\t", depth) + line + "\n")
+		}
+	}
+	for idx, in := range instrs {
+		if gotos[in.Addr] {
+			write(fmt.Sprintf("L%d:", in.Addr))
+		}
+		for range loops[idx] {
+			write("for {")
+			depth++
+		}
+		if closes[idx] {
+			write("if a == 0 {\n\tbreak\n}")
+			depth--
+			write("}")
+			continue
+		}
+		write(in.goStatement())
+	}
+	return sb.String()
+}
+
+// jumpsInto reports whether any jump outside the instructions from start to end lands inside
+// them, which would stop them being rendered as a plain loop.
+func jumpsInto(instrs []Instr, start, end int) bool {
+	for idx, in := range instrs {
+		if in.Op != OpJnz || (idx >= start && idx <= end) {
+			continue
+		}
+		target := int(in.Operand) / 2
+		if target > start && target <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// goStatement is the instruction as a Go-like statement.
+func (in Instr) goStatement() string {
+	x := in.goCombo()
+	switch in.Op {
+	case OpAdv:
+		return "a >>= " + x
+	case OpBxl:
+		return fmt.Sprintf("b ^= %d", in.Operand)
+	case OpBst:
+		return "b = " + in.mod8(x)
+	case OpJnz:
+		return fmt.Sprintf("if a != 0 {\n\tgoto L%d\n}", in.Operand)
+	case OpBxc:
+		return "b ^= c"
+	case OpOut:
+		return "out(" + in.mod8(x) + ")"
+	case OpBdv:
+		return "b = a >> " + x
+	case OpCdv:
+		return "c = a >> " + x
+	}
+	return fmt.Sprintf("invalid(%d, %d)", in.Op, in.Operand)
+}
+
+// goCombo is the combo operand as a Go-like expression.
+func (in Instr) goCombo() string {
+	if in.Operand == reservedCombo {
+		return `panic("reserved combo operand 7")`
+	}
+	return strings.ToLower(in.operand())
+}
+
+// mod8 is x % 8, folded away for literals, which are always below 8 already.
+func (in Instr) mod8(x string) string {
+	if in.Operand < 4 { //nolint:mnd // 0-3 are literals
+		return x
+	}
+	return x + " % 8"
+}
@@ -0,0 +1,128 @@
+package day17_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/2024/day17"
+	"github.com/jstensland/advent-of-code/2024/input"
+)
+
+func TestDisassembleInput(t *testing.T) {
+	computer, err := day17.ParseIn(input.Reader("./input.txt"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `L0:
+    bst A        ; 0: 2,4
+    bxl 2        ; 2: 1,2
+    cdv B        ; 4: 7,5
+    bxl 3        ; 6: 1,3
+    bxc 3        ; 8: 4,3
+    out B        ; 10: 5,5
+    adv 3        ; 12: 0,3
+    jnz 0        ; 14: 3,0
+`, computer.Program.Disassemble())
+}
+
+func TestPseudoGoInput(t *testing.T) {
+	computer, err := day17.ParseIn(input.Reader("./input.txt"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `for {
+	b = a % 8
+	b ^= 2
+	c = a >> b
+	b ^= 3
+	b ^= c
+	out(b % 8)
+	a >>= 3
+	if a == 0 {
+		break
+	}
+}
+`, computer.Program.PseudoGo())
+}
+
+func TestDisassemble(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data []uint8
+		want string
+	}{
+		{
+			desc: "no jumps, no labels",
+			data: []uint8{5, 0, 5, 1, 5, 4},
+			want: "    out 0        ; 0: 5,0\n    out 1        ; 2: 5,1\n    out A        ; 4: 5,4\n",
+		},
+		{
+			desc: "literal operands stay numbers",
+			data: []uint8{1, 7, 4, 0},
+			want: "    bxl 7        ; 0: 1,7\n    bxc          ; 2: 4,0\n",
+		},
+		{
+			desc: "reserved combo operand is flagged",
+			data: []uint8{2, 7},
+			want: "    bst 7        ; 0: 2,7 reserved combo operand\n",
+		},
+		{
+			desc: "jump into the middle of the program",
+			data: []uint8{0, 1, 5, 6, 3, 2},
+			want: "    adv 1        ; 0: 0,1\nL2:\n    out C        ; 2: 5,6\n    jnz 2        ; 4: 3,2\n",
+		},
+		{
+			desc: "misaligned jump and trailing opcode",
+			data: []uint8{3, 3, 5},
+			want: "    jnz 3        ; 0: 3,3 jumps off the program or between instructions\n" +
+				"    ; 2: 5 without an operand halts\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.want, day17.NewProgram(tC.data).Disassemble())
+		})
+	}
+}
+
+func TestPseudoGo(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data []uint8
+		want string
+	}{
+		{
+			desc: "straight line",
+			data: []uint8{2, 6, 5, 1},
+			want: "b = c % 8\nout(1)\n",
+		},
+		{
+			desc: "loop after setup",
+			data: []uint8{6, 1, 0, 1, 5, 4, 3, 2},
+			want: "b = a >> 1\nfor {\n\ta >>= 1\n\tout(a % 8)\n\tif a == 0 {\n\t\tbreak\n\t}\n}\n",
+		},
+		{
+			desc: "nested loops",
+			data: []uint8{0, 1, 5, 4, 3, 2, 3, 0},
+			want: "for {\n\ta >>= 1\n\tfor {\n\t\tout(a % 8)\n\t\tif a == 0 {\n\t\t\tbreak\n\t\t}\n\t}\n" +
+				"\tif a == 0 {\n\t\tbreak\n\t}\n}\n",
+		},
+		{
+			desc: "forward jump is a goto",
+			data: []uint8{3, 4, 5, 0, 5, 1},
+			want: "if a != 0 {\n\tgoto L4\n}\nout(0)\nL4:\nout(1)\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.want, day17.NewProgram(tC.data).PseudoGo())
+		})
+	}
+}
+
+func TestOpCode(t *testing.T) {
+	assert.Equal(t, "cdv", day17.OpCdv.Mnemonic())
+	assert.True(t, day17.OpOut.Combo())
+	assert.False(t, day17.OpBxl.Combo())
+	assert.Equal(t, "op8", day17.OpCode(8).Mnemonic())
+}
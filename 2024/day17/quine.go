@@ -0,0 +1,124 @@
+package day17
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Errors from FindQuine.
+var (
+	ErrNotShiftLoop = errors.New("program is not a loop shifting A by 3 bits")
+	ErrNoQuine      = errors.New("no value of A makes the program output itself")
+)
+
+// bitsPerLoop is how far the loop shifts A each time round, one octal digit.
+const bitsPerLoop = 3
+
+// SolvePart2 finds the lowest value for register A that makes the program output a copy of
+// itself.
+func SolvePart2(in io.Reader) (int, error) {
+	computer, err := ParseIn(in)
+	if err != nil {
+		return 0, fmt.Errorf("error loading input: %w", err)
+	}
+	return computer.FindQuine()
+}
+
+// FindQuine finds the lowest value for register A that makes the program output itself.
+//
+// It only works for programs shaped like the puzzle's: one loop back to the start, ending in
+// jnz 0, with a single adv 3 in it. Each time round, the loop writes a value worked out from
+// the low bits of A, then drops the lowest 3 bits of A, so the last output depends only on the
+// highest 3 bits of A, the one before on the highest 6 and so on.
+//
+// So the search goes backwards through the program. For each octal digit of A, from the
+// highest, it tries 0 to 7 after the digits found so far and keeps those where the program
+// outputs the right tail of itself. Trying them in order and going deep first means the first
+// A to output the whole program is the lowest.
+func (c *Computer) FindQuine() (int, error) {
+	if err := c.checkBackwardsSearch(); err != nil {
+		return 0, err
+	}
+	regB, regC := c.registerB, c.registerC
+	data := c.Program.data
+	compiled := c.Program.Compile()
+
+	var search func(idx, highBits int) (int, bool)
+	search = func(idx, highBits int) (int, bool) {
+		if idx < 0 {
+			return highBits, true
+		}
+		for digit := range 1 << bitsPerLoop {
+			regA := highBits<<bitsPerLoop | digit
+			if regA == 0 {
+				continue // A has an octal digit per output, so the highest isn't 0
+			}
+			if !compiled.Outputs(regA, regB, regC, data[idx:]) {
+				continue
+			}
+			if found, ok := search(idx-1, regA); ok {
+				return found, true
+			}
+		}
+		return 0, false
+	}
+
+	found, ok := search(len(data)-1, 0)
+	if !ok {
+		return 0, ErrNoQuine
+	}
+	return found, nil
+}
+
+// checkBackwardsSearch checks the search can work back from the last output: the program is a
+// loop shifting A by 3 bits, and a symbolic run shows each output only depends on bits of A
+// above those the outputs before it used up.
+func (c *Computer) checkBackwardsSearch() error {
+	if err := c.Program.checkShiftLoop(); err != nil {
+		return err
+	}
+	outs, err := c.Symbolic(bitsPerLoop * len(c.Program.data))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrNotShiftLoop, err)
+	}
+	if len(outs) != len(c.Program.data) {
+		return fmt.Errorf("%w: %d outputs for %d values of the program", ErrNotShiftLoop, len(outs), len(c.Program.data))
+	}
+	for idx, out := range outs {
+		if lower := uint64(1)<<(bitsPerLoop*idx) - 1; out.Deps&lower != 0 {
+			return fmt.Errorf("%w: output %d depends on bits %s of A", ErrNotShiftLoop, idx, bitRanges(out.DepBits()))
+		}
+	}
+	return nil
+}
+
+// checkShiftLoop checks the program is a single loop back to the start that shifts A right
+// by exactly 3 bits each time round.
+func (p *Program) checkShiftLoop() error {
+	instrs := p.Instructions()
+	if len(instrs) == 0 || len(p.data)%2 == 1 {
+		return fmt.Errorf("%w: not a whole number of instructions", ErrNotShiftLoop)
+	}
+	if last := instrs[len(instrs)-1]; last.Op != OpJnz || last.Operand != 0 {
+		return fmt.Errorf("%w: does not end with jnz 0", ErrNotShiftLoop)
+	}
+
+	shifts, jumps := 0, 0
+	for _, in := range instrs {
+		switch in.Op {
+		case OpAdv:
+			if in.Operand != bitsPerLoop {
+				return fmt.Errorf("%w: %v at %d", ErrNotShiftLoop, in, in.Addr)
+			}
+			shifts++
+		case OpJnz:
+			jumps++
+		case OpBxl, OpBst, OpBxc, OpOut, OpBdv, OpCdv:
+		}
+	}
+	if shifts != 1 || jumps != 1 {
+		return fmt.Errorf("%w: %d adv 3 and %d jnz, want one of each", ErrNotShiftLoop, shifts, jumps)
+	}
+	return nil
+}
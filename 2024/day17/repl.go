@@ -0,0 +1,190 @@
+package day17
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrCommand is returned for a REPL command that can't be understood.
+var ErrCommand = errors.New("bad command")
+
+const (
+	replPrompt = "(day17) "
+	replHelp   = `commands:
+  s, step [n]           run n instructions (default 1)
+  c, run                run to a breakpoint, condition, watchpoint or the end
+  b, break <ip>         break before the instruction at ip
+  clear <ip>            remove the breakpoint at ip
+  when <reg> <op> <n>   break once a register compares true, op is one of == != < <= > >=
+  watch [n]             break after output n, or after any output
+  r, regs               show the registers and output
+  back [n]              rewind n instructions (default 1)
+  trace [n]             show the last n instructions run (default 10)
+  l, list               show the program
+  deps [bits]           show each output as an expression of A and the bits of A it reads
+  q, quit               leave
+`
+	defaultTraceLines = 10
+)
+
+// REPL reads debugger commands from in, one per line, and writes what they show to out, until
+// quit or the end of in. Bad commands are reported to out rather than stopping it.
+func (d *Debugger) REPL(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	fmt.Fprint(out, replPrompt)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 {
+			if fields[0] == "q" || fields[0] == "quit" {
+				return nil
+			}
+			if err := d.command(fields[0], fields[1:], out); err != nil {
+				fmt.Fprintln(out, "error:", err)
+			}
+		}
+		fmt.Fprint(out, replPrompt)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading commands: %w", err)
+	}
+	return nil
+}
+
+func (d *Debugger) command(cmd string, args []string, out io.Writer) error {
+	switch cmd {
+	case "s", "step":
+		n, err := countArg(args, 1)
+		if err != nil {
+			return err
+		}
+		for range n {
+			if !d.Step() {
+				fmt.Fprintln(out, "halted")
+				break
+			}
+		}
+		fmt.Fprintln(out, d.State())
+	case "c", "run":
+		fmt.Fprintln(out, d.Run(nil))
+	case "b", "break", "clear":
+		ip, err := intArg(args)
+		if err != nil {
+			return err
+		}
+		if cmd == "clear" {
+			d.ClearBreak(ip)
+		} else {
+			d.Break(ip)
+		}
+	case "when":
+		return d.when(args)
+	case "watch":
+		if len(args) == 0 {
+			d.Watch("any output", nil)
+			return nil
+		}
+		want, err := intArg(args)
+		if err != nil {
+			return err
+		}
+		d.Watch("output "+args[0], func(val int) bool { return val == want })
+	case "r", "regs":
+		fmt.Fprintln(out, d.State())
+	case "back":
+		n, err := countArg(args, 1)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "rewound %d\n%v\n", d.Rewind(n), d.State())
+	case "trace":
+		n, err := countArg(args, defaultTraceLines)
+		if err != nil {
+			return err
+		}
+		for _, entry := range d.trace[max(0, len(d.trace)-n):] {
+			fmt.Fprintf(out, "%-8v from %v\n", entry.Instr, entry.Before)
+		}
+	case "l", "list":
+		fmt.Fprint(out, d.Listing())
+	case "deps":
+		width, err := countArg(args, bitsPerLoop*len(d.c.Program.data))
+		if err != nil {
+			return err
+		}
+		outs, err := d.c.Symbolic(min(width, 63)) //nolint:mnd // widest A it can handle
+		for _, o := range outs {
+			fmt.Fprintln(out, o)
+		}
+		return err
+	case "h", "help":
+		fmt.Fprint(out, replHelp)
+	default:
+		return fmt.Errorf("%w: %s, try help", ErrCommand, cmd)
+	}
+	return nil
+}
+
+// when adds a register condition from arguments like "A == 0".
+func (d *Debugger) when(args []string) error {
+	if len(args) != 3 { //nolint:mnd // register, op, value
+		return fmt.Errorf("%w: when takes a register, an op and a number", ErrCommand)
+	}
+	want, err := strconv.Atoi(args[2])
+	if err != nil {
+		return fmt.Errorf("%w: %s is not a number", ErrCommand, args[2])
+	}
+
+	var reg func(State) int
+	switch strings.ToUpper(args[0]) {
+	case "A":
+		reg = func(s State) int { return s.A }
+	case "B":
+		reg = func(s State) int { return s.B }
+	case "C":
+		reg = func(s State) int { return s.C }
+	default:
+		return fmt.Errorf("%w: %s is not a register", ErrCommand, args[0])
+	}
+
+	compare, ok := map[string]func(a, b int) bool{
+		"==": func(a, b int) bool { return a == b },
+		"!=": func(a, b int) bool { return a != b },
+		"<":  func(a, b int) bool { return a < b },
+		"<=": func(a, b int) bool { return a <= b },
+		">":  func(a, b int) bool { return a > b },
+		">=": func(a, b int) bool { return a >= b },
+	}[args[1]]
+	if !ok {
+		return fmt.Errorf("%w: unknown comparison %s", ErrCommand, args[1])
+	}
+
+	d.BreakWhen(strings.Join(args, " "), func(s State) bool { return compare(reg(s), want) })
+	return nil
+}
+
+// countArg reads an optional positive count.
+func countArg(args []string, fallback int) (int, error) {
+	if len(args) == 0 {
+		return fallback, nil
+	}
+	n, err := intArg(args)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%w: %s is not a positive count", ErrCommand, args[0])
+	}
+	return n, nil
+}
+
+func intArg(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("%w: expected one number", ErrCommand)
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s is not a number", ErrCommand, args[0])
+	}
+	return n, nil
+}
@@ -0,0 +1,284 @@
+package day17
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Errors from Symbolic.
+var (
+	ErrWidth        = errors.New("width of A must be 1-63 bits")
+	ErrSymbolicStep = errors.New("symbolic run did not halt")
+)
+
+// maxSymbolicSteps bounds a symbolic run, which can't always tell whether a loop ends.
+const maxSymbolicSteps = 100_000
+
+// ExprOp is the kind of an Expr node.
+type ExprOp int
+
+const (
+	ExprConst ExprOp = iota // a known value
+	ExprA                   // the starting value of register A
+	ExprShr                 // Args[0] >> Args[1], which is how adv, bdv and cdv divide
+	ExprXor                 // Args[0] ^ Args[1]
+	ExprMod8                // Args[0] % 8
+)
+
+// Expr is a value in a symbolic run, as a tree over the starting value of A.
+type Expr struct {
+	Op   ExprOp
+	Val  int // for ExprConst
+	Args []*Expr
+}
+
+func constExpr(val int) *Expr { return &Expr{Op: ExprConst, Val: val} }
+
+// shr builds x >> y, folding constants.
+func shr(x, y *Expr) *Expr {
+	switch {
+	case y.Op == ExprConst && y.Val == 0:
+		return x
+	case x.Op == ExprConst && y.Op == ExprConst:
+		return constExpr(div(x.Val, y.Val))
+	case x.Op == ExprShr && y.Op == ExprConst && x.Args[1].Op == ExprConst:
+		if total := x.Args[1].Val + y.Val; total < 63 { //nolint:mnd // A is at most 63 bits
+			return shr(x.Args[0], constExpr(total)) // (A >> 3) >> 3 is A >> 6
+		}
+		return constExpr(0) // shifted every bit of A away
+	}
+	return &Expr{Op: ExprShr, Args: []*Expr{x, y}}
+}
+
+// xor builds x ^ y, folding constants.
+func xor(x, y *Expr) *Expr {
+	if x.Op == ExprConst {
+		x, y = y, x // keep any constant on the right
+	}
+	switch {
+	case y.Op == ExprConst && y.Val == 0:
+		return x
+	case x.Op == ExprConst:
+		return constExpr(x.Val ^ y.Val)
+	case y.Op == ExprConst && x.Op == ExprXor && x.Args[1].Op == ExprConst:
+		return xor(x.Args[0], constExpr(x.Args[1].Val^y.Val)) // (x ^ 2) ^ 3 is x ^ 1
+	}
+	return &Expr{Op: ExprXor, Args: []*Expr{x, y}}
+}
+
+// mod8 builds x % 8, folding constants and repeats.
+func mod8(x *Expr) *Expr {
+	switch x.Op {
+	case ExprConst:
+		return constExpr(x.Val % 8) //nolint:mnd // 3 bits
+	case ExprMod8:
+		return x
+	case ExprA, ExprShr, ExprXor:
+	}
+	return &Expr{Op: ExprMod8, Args: []*Expr{x}}
+}
+
+func (e *Expr) String() string {
+	switch e.Op {
+	case ExprConst:
+		return strconv.Itoa(e.Val)
+	case ExprA:
+		return "A"
+	case ExprShr:
+		return fmt.Sprintf("(%v >> %v)", e.Args[0], e.Args[1])
+	case ExprXor:
+		return fmt.Sprintf("(%v ^ %v)", e.Args[0], e.Args[1])
+	case ExprMod8:
+		return fmt.Sprintf("(%v %% 8)", e.Args[0])
+	}
+	return "?"
+}
+
+// Eval works out the expression for a starting value of A.
+func (e *Expr) Eval(regA int) int {
+	switch e.Op {
+	case ExprConst:
+		return e.Val
+	case ExprA:
+		return regA
+	case ExprShr:
+		return div(e.Args[0].Eval(regA), e.Args[1].Eval(regA))
+	case ExprXor:
+		return e.Args[0].Eval(regA) ^ e.Args[1].Eval(regA)
+	case ExprMod8:
+		return e.Args[0].Eval(regA) % 8 //nolint:mnd // 3 bits
+	}
+	panic("unknown expression op " + strconv.Itoa(int(e.Op)))
+}
+
+// maxVal is the largest value the expression can have when A has width bits.
+func (e *Expr) maxVal(width int) int {
+	switch e.Op {
+	case ExprConst:
+		return e.Val
+	case ExprA:
+		return 1<<width - 1
+	case ExprShr:
+		if shift := e.Args[1]; shift.Op == ExprConst {
+			if shift.Val >= 63 { //nolint:mnd // shifts every bit away
+				return 0
+			}
+			return e.Args[0].maxVal(width) >> shift.Val
+		}
+		return e.Args[0].maxVal(width)
+	case ExprXor:
+		return 1<<bits.Len(uint(max(e.Args[0].maxVal(width), e.Args[1].maxVal(width)))) - 1
+	case ExprMod8:
+		return min(e.Args[0].maxVal(width), 7) //nolint:mnd // 3 bits
+	}
+	return 0
+}
+
+// bitDeps works out, for each bit of the expression's value, which bits of A it depends on.
+func (e *Expr) bitDeps(width int) [64]uint64 {
+	var out [64]uint64
+	switch e.Op {
+	case ExprConst:
+	case ExprA:
+		for i := range width {
+			out[i] = 1 << i
+		}
+	case ExprShr:
+		x := e.Args[0].bitDeps(width)
+		shift := e.Args[1]
+		if shift.Op == ExprConst {
+			for i := 0; i+shift.Val < len(out); i++ {
+				out[i] = x[i+shift.Val]
+			}
+			break
+		}
+		// any shift it could be, and whatever picks the shift
+		var shiftDeps uint64
+		for _, mask := range shift.bitDeps(width) {
+			shiftDeps |= mask
+		}
+		maxShift := min(shift.maxVal(width), len(out)-1)
+		for i := range out {
+			out[i] = shiftDeps
+			for s := 0; s <= maxShift && i+s < len(out); s++ {
+				out[i] |= x[i+s]
+			}
+		}
+	case ExprXor:
+		x, y := e.Args[0].bitDeps(width), e.Args[1].bitDeps(width)
+		for i := range out {
+			out[i] = x[i] | y[i]
+		}
+	case ExprMod8:
+		x := e.Args[0].bitDeps(width)
+		copy(out[:3], x[:3])
+	}
+	return out
+}
+
+// SymOutput is one out instruction from a symbolic run.
+type SymOutput struct {
+	Loop int    // how many times the program had jumped back before it
+	Expr *Expr  // the value written, in terms of A
+	Deps uint64 // bit i is set if the value depends on bit i of A
+}
+
+// DepBits lists the bits of A the output depends on, lowest first.
+func (o SymOutput) DepBits() []int {
+	var out []int
+	for mask := o.Deps; mask != 0; mask &= mask - 1 {
+		out = append(out, bits.TrailingZeros64(mask))
+	}
+	return out
+}
+
+func (o SymOutput) String() string {
+	return fmt.Sprintf("loop %d: A bits %s: %v", o.Loop, bitRanges(o.DepBits()), o.Expr)
+}
+
+// bitRanges writes sorted bits as ranges, e.g. "0-2,5".
+func bitRanges(bitList []int) string {
+	if len(bitList) == 0 {
+		return "none"
+	}
+	var parts []string
+	for start := 0; start < len(bitList); {
+		end := start
+		for end+1 < len(bitList) && bitList[end+1] == bitList[end]+1 {
+			end++
+		}
+		if start == end {
+			parts = append(parts, strconv.Itoa(bitList[start]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", bitList[start], bitList[end]))
+		}
+		start = end + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// Symbolic runs the program with register A as an unknown of width bits, and B and C as they
+// are now. It returns what each out instruction writes, as an expression over A, along with
+// the bits of A it depends on.
+//
+// A jnz is taken whenever A could still be non-zero, so the run follows the path where all
+// width bits of A matter. For the puzzle programs, shifting A by 3 bits each loop, a width of
+// 3 bits per output gives one loop per output.
+func (c *Computer) Symbolic(width int) ([]SymOutput, error) {
+	if width < 1 || width > 63 {
+		return nil, fmt.Errorf("%w: %d", ErrWidth, width)
+	}
+
+	data := c.Program.data
+	regs := [...]*Expr{srcLiteral: nil, srcA: {Op: ExprA}, srcB: constExpr(c.registerB), srcC: constExpr(c.registerC)}
+	var outs []SymOutput
+	loop := 0
+	for ip, steps := 0, 0; ip+1 < len(data); steps++ {
+		if steps == maxSymbolicSteps {
+			return outs, fmt.Errorf("%w after %d instructions", ErrSymbolicStep, steps)
+		}
+		code, operand := OpCode(data[ip]), data[ip+1]
+		ip += 2
+
+		x := constExpr(int(operand))
+		if code.Combo() {
+			src := comboSource(operand)
+			if src > srcC {
+				return outs, fmt.Errorf("%w at %d", ErrReservedCombo, ip-2)
+			}
+			if src != srcLiteral {
+				x = regs[src]
+			}
+		}
+
+		switch code {
+		case OpAdv:
+			regs[srcA] = shr(regs[srcA], x)
+		case OpBxl:
+			regs[srcB] = xor(regs[srcB], x)
+		case OpBst:
+			regs[srcB] = mod8(x)
+		case OpJnz:
+			if regs[srcA].maxVal(width) != 0 {
+				ip = int(operand)
+				loop++
+			}
+		case OpBxc:
+			regs[srcB] = xor(regs[srcB], regs[srcC])
+		case OpOut:
+			val := mod8(x)
+			deps := val.bitDeps(width)
+			outs = append(outs, SymOutput{Loop: loop, Expr: val, Deps: deps[0] | deps[1] | deps[2]})
+		case OpBdv:
+			regs[srcB] = shr(regs[srcA], x)
+		case OpCdv:
+			regs[srcC] = shr(regs[srcA], x)
+		default:
+			return outs, fmt.Errorf("%w: opcode %d at %d", ErrUnknownMnemonic, code, ip-2)
+		}
+	}
+	return outs, nil
+}
@@ -0,0 +1,93 @@
+package day17_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/2024/day17"
+)
+
+func TestSymbolicInput(t *testing.T) {
+	computer := inputComputer(t)
+
+	outs, err := computer.Symbolic(48)
+
+	require.NoError(t, err)
+	require.Len(t, outs, 16, "one loop per octal digit of A")
+	assert.Equal(t, "((((A % 8) ^ 1) ^ (A >> ((A % 8) ^ 2))) % 8)", outs[0].Expr.String())
+	assert.Equal(t, "loop 0: A bits 0-9: ((((A % 8) ^ 1) ^ (A >> ((A % 8) ^ 2))) % 8)", outs[0].String())
+	for idx, out := range outs {
+		assert.Equal(t, idx, out.Loop)
+		bits := out.DepBits()
+		assert.Equal(t, 3*idx, bits[0], "output %d starts at its own octal digit", idx)
+		assert.LessOrEqual(t, bits[len(bits)-1], min(3*idx+9, 47), "and reads at most 7 bits above it")
+	}
+	assert.Equal(t, []int{45, 46, 47}, outs[15].DepBits(), "the last digit only sees the top of A")
+}
+
+func TestSymbolicPart2Example(t *testing.T) {
+	computer, err := day17.ParseIn(Part2Example())
+	require.NoError(t, err)
+
+	outs, err := computer.Symbolic(18)
+
+	require.NoError(t, err)
+	require.Len(t, outs, 6)
+	assert.Equal(t, "((A >> 3) % 8)", outs[0].Expr.String())
+	assert.Equal(t, "((A >> 15) % 8)", outs[4].Expr.String())
+	assert.Equal(t, []int{3, 4, 5}, outs[0].DepBits())
+	assert.Empty(t, outs[5].DepBits(), "A has been shifted away by the last loop")
+	assert.Equal(t, "loop 5: A bits none: ((A >> 18) % 8)", outs[5].String())
+}
+
+// TestSymbolicEval checks the expressions give the same outputs as running the program, for
+// values of A with all their bits in use.
+func TestSymbolicEval(t *testing.T) {
+	programs := map[string][]uint8{
+		"input":             inputComputer(t).GetData(),
+		"part 2 example":    {0, 3, 5, 4, 3, 0},
+		"all the registers": {2, 4, 7, 5, 6, 1, 1, 7, 4, 0, 5, 5, 5, 6, 0, 3, 3, 0},
+	}
+	rng := rand.New(rand.NewPCG(17, 2024)) //nolint:gosec // repeatable test values
+	for desc, data := range programs {
+		t.Run(desc, func(t *testing.T) {
+			const width = 30
+			outs, err := day17.NewComputer(0, 3, 5, data).Symbolic(width)
+			require.NoError(t, err)
+
+			for range 500 {
+				regA := 1<<(width-1) | rng.IntN(1<<(width-1))
+				want := day17.NewProgram(data).Compile().Run(regA, 3, 5, nil)
+
+				got := make([]int, len(outs))
+				for idx, out := range outs {
+					got[idx] = out.Expr.Eval(regA)
+				}
+				require.Equal(t, want, got, "A=%d", regA)
+			}
+		})
+	}
+}
+
+func TestSymbolicErrors(t *testing.T) {
+	_, err := day17.NewComputer(0, 0, 0, []uint8{0, 3}).Symbolic(64)
+	require.ErrorIs(t, err, day17.ErrWidth)
+
+	_, err = day17.NewComputer(0, 0, 0, []uint8{5, 7}).Symbolic(8)
+	require.ErrorIs(t, err, day17.ErrReservedCombo)
+
+	// shifting A by B, which is always 0, never gets A to 0
+	_, err = day17.NewComputer(0, 0, 0, []uint8{0, 5, 3, 0}).Symbolic(8)
+	require.ErrorIs(t, err, day17.ErrSymbolicStep)
+}
+
+func TestFindQuine_OutputReadsLowerBits(t *testing.T) {
+	// the shape of a shift loop, but C carries A over to the next time round, so each output
+	// reads the digit below its own and the backwards search can't settle digits in turn
+	_, err := day17.NewComputer(0, 0, 0, []uint8{5, 6, 7, 0, 0, 3, 3, 0}).FindQuine()
+
+	require.ErrorIs(t, err, day17.ErrNotShiftLoop)
+}
@@ -2,11 +2,10 @@
 package day4
 
 import (
-	"bufio"
 	"fmt"
 	"io"
-	"iter"
-	"log"
+
+	"github.com/jstensland/advent-of-code/lib/grid"
 )
 
 // SolvePart1 finds occurrences of XMAS in a wordsearch fashion.
@@ -32,54 +31,29 @@ func SolvePart2(in io.Reader) (int, error) {
 	return grid.XmasCount2(), nil
 }
 
+// Grid is the word search.
 type Grid struct {
-	data   [][]rune
-	width  int
-	height int
+	*grid.Grid[rune]
 }
 
 func ParseGrid(in io.Reader) (Grid, error) {
-	scanner := bufio.NewScanner(in)
-	grid := Grid{[][]rune{}, 0, 0}
-	for scanner.Scan() {
-		row := []rune(scanner.Text())
-		grid.data = append(grid.data, row)
-		grid.height++
-	}
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+	g, err := grid.Parse(in, grid.Runes)
+	if err != nil {
+		return Grid{}, fmt.Errorf("failed to parse word search: %w", err)
 	}
-
-	grid.width = len(grid.data[0])
-	return grid, nil
+	return Grid{g}, nil
 }
 
-func (g Grid) Width() int  { return g.width }
-func (g Grid) Height() int { return g.height }
-
-type position struct {
-	row int
-	col int
-}
-
-func (g Grid) positions() iter.Seq[position] {
-	return func(yield func(r position) bool) {
-		for row := range g.height {
-			for col := range g.width {
-				if !yield(position{row, col}) {
-					return
-				}
-			}
-		}
-	}
+func (g Grid) at(row, col int) rune {
+	return g.At(grid.Pos{Row: row, Col: col})
 }
 
 // XmasCount counts the number of XMAS in a row.
 // Each X could have up to 8 XMAS.
 func (g Grid) XmasCount() int {
 	count := 0
-	for loc := range g.positions() {
-		// fmt.Printf("looking at row %v and column %v\n", loc.row, loc.col)
+	for loc := range g.Positions() {
+		// fmt.Printf("looking at row %v and column %v\n", loc.Row, loc.Col)
 		count += g.checkPosition(loc)
 	}
 
@@ -89,9 +63,9 @@ func (g Grid) XmasCount() int {
 // IMPROVEMENT: write specific tests for each to avoid print statement visual parsing
 // for issues. And use a more generic check with direction as the input
 
-func (g Grid) checkPosition(loc position) int {
+func (g Grid) checkPosition(loc grid.Pos) int {
 	// Check if it's an X. If not, continue.
-	if g.data[loc.row][loc.col] != 'X' {
+	if g.at(loc.Row, loc.Col) != 'X' {
 		return 0
 	}
 
@@ -134,76 +108,76 @@ func (g Grid) checkPosition(loc position) int {
 // These should be reduced... the same function could take
 // the loc, the word, and the direction...
 
-func (g Grid) checkRight(loc position) bool {
-	if loc.col+3 >= g.width {
+func (g Grid) checkRight(loc grid.Pos) bool {
+	if loc.Col+3 >= g.Width() {
 		return false
 	}
-	return g.data[loc.row][loc.col+1] == 'M' &&
-		g.data[loc.row][loc.col+2] == 'A' &&
-		g.data[loc.row][loc.col+3] == 'S'
+	return g.at(loc.Row, loc.Col+1) == 'M' &&
+		g.at(loc.Row, loc.Col+2) == 'A' &&
+		g.at(loc.Row, loc.Col+3) == 'S'
 }
 
-func (g Grid) checkRightUp(loc position) bool {
-	if loc.col+3 >= g.width || loc.row-3 < 0 {
+func (g Grid) checkRightUp(loc grid.Pos) bool {
+	if loc.Col+3 >= g.Width() || loc.Row-3 < 0 {
 		return false
 	}
-	return g.data[loc.row-1][loc.col+1] == 'M' &&
-		g.data[loc.row-2][loc.col+2] == 'A' &&
-		g.data[loc.row-3][loc.col+3] == 'S'
+	return g.at(loc.Row-1, loc.Col+1) == 'M' &&
+		g.at(loc.Row-2, loc.Col+2) == 'A' &&
+		g.at(loc.Row-3, loc.Col+3) == 'S'
 }
 
-func (g Grid) checkRightDown(loc position) bool {
-	if loc.col+3 >= g.width || loc.row+3 >= g.height {
+func (g Grid) checkRightDown(loc grid.Pos) bool {
+	if loc.Col+3 >= g.Width() || loc.Row+3 >= g.Height() {
 		return false
 	}
-	return g.data[loc.row+1][loc.col+1] == 'M' &&
-		g.data[loc.row+2][loc.col+2] == 'A' &&
-		g.data[loc.row+3][loc.col+3] == 'S'
+	return g.at(loc.Row+1, loc.Col+1) == 'M' &&
+		g.at(loc.Row+2, loc.Col+2) == 'A' &&
+		g.at(loc.Row+3, loc.Col+3) == 'S'
 }
 
-func (g Grid) checkUp(loc position) bool {
-	if loc.row-3 < 0 {
+func (g Grid) checkUp(loc grid.Pos) bool {
+	if loc.Row-3 < 0 {
 		return false
 	}
-	return g.data[loc.row-1][loc.col] == 'M' &&
-		g.data[loc.row-2][loc.col] == 'A' &&
-		g.data[loc.row-3][loc.col] == 'S'
+	return g.at(loc.Row-1, loc.Col) == 'M' &&
+		g.at(loc.Row-2, loc.Col) == 'A' &&
+		g.at(loc.Row-3, loc.Col) == 'S'
 }
 
-func (g Grid) checkDown(loc position) bool {
-	if loc.row+3 >= g.height {
+func (g Grid) checkDown(loc grid.Pos) bool {
+	if loc.Row+3 >= g.Height() {
 		return false
 	}
-	return g.data[loc.row+1][loc.col] == 'M' &&
-		g.data[loc.row+2][loc.col] == 'A' &&
-		g.data[loc.row+3][loc.col] == 'S'
+	return g.at(loc.Row+1, loc.Col) == 'M' &&
+		g.at(loc.Row+2, loc.Col) == 'A' &&
+		g.at(loc.Row+3, loc.Col) == 'S'
 }
 
-func (g Grid) checkLeftDown(loc position) bool {
-	if loc.col-3 < 0 || loc.row+3 >= g.height {
+func (g Grid) checkLeftDown(loc grid.Pos) bool {
+	if loc.Col-3 < 0 || loc.Row+3 >= g.Height() {
 		return false
 	}
-	return g.data[loc.row+1][loc.col-1] == 'M' &&
-		g.data[loc.row+2][loc.col-2] == 'A' &&
-		g.data[loc.row+3][loc.col-3] == 'S'
+	return g.at(loc.Row+1, loc.Col-1) == 'M' &&
+		g.at(loc.Row+2, loc.Col-2) == 'A' &&
+		g.at(loc.Row+3, loc.Col-3) == 'S'
 }
 
-func (g Grid) checkLeft(loc position) bool {
-	if loc.col-3 < 0 {
+func (g Grid) checkLeft(loc grid.Pos) bool {
+	if loc.Col-3 < 0 {
 		return false
 	}
-	return g.data[loc.row][loc.col-1] == 'M' &&
-		g.data[loc.row][loc.col-2] == 'A' &&
-		g.data[loc.row][loc.col-3] == 'S'
+	return g.at(loc.Row, loc.Col-1) == 'M' &&
+		g.at(loc.Row, loc.Col-2) == 'A' &&
+		g.at(loc.Row, loc.Col-3) == 'S'
 }
 
-func (g Grid) checkLeftUp(loc position) bool {
-	if loc.row-3 < 0 || loc.col-3 < 0 {
+func (g Grid) checkLeftUp(loc grid.Pos) bool {
+	if loc.Row-3 < 0 || loc.Col-3 < 0 {
 		return false
 	}
-	return g.data[loc.row-1][loc.col-1] == 'M' &&
-		g.data[loc.row-2][loc.col-2] == 'A' &&
-		g.data[loc.row-3][loc.col-3] == 'S'
+	return g.at(loc.Row-1, loc.Col-1) == 'M' &&
+		g.at(loc.Row-2, loc.Col-2) == 'A' &&
+		g.at(loc.Row-3, loc.Col-3) == 'S'
 }
 
 // XmasCount2 looks for these shapes
@@ -212,21 +186,21 @@ func (g Grid) checkLeftUp(loc position) bool {
 // M.S
 func (g Grid) XmasCount2() int {
 	count := 0
-	for loc := range g.positions() {
-		// fmt.Printf("looking at row %v and column %v\n", loc.row, loc.col)
+	for loc := range g.Positions() {
+		// fmt.Printf("looking at row %v and column %v\n", loc.Row, loc.Col)
 		count += g.checkPosition2(loc)
 	}
 
 	return count
 }
 
-func (g Grid) checkPosition2(loc position) int {
+func (g Grid) checkPosition2(loc grid.Pos) int {
 	// Orient around A characters. If it's not an A, return false
-	if g.data[loc.row][loc.col] != 'A' {
+	if g.at(loc.Row, loc.Col) != 'A' {
 		return 0
 	}
 	// if it's on the edge, return false
-	if loc.col == 0 || loc.row == 0 || loc.col == g.width-1 || loc.row == g.height-1 {
+	if loc.Col == 0 || loc.Row == 0 || loc.Col == g.Width()-1 || loc.Row == g.Height()-1 {
 		return 0
 	}
 
@@ -250,10 +224,10 @@ func (g Grid) checkPosition2(loc position) int {
 	// S.S
 
 	// just check that corner are opposites
-	upperLeft := g.data[loc.row-1][loc.col-1]
-	upperRight := g.data[loc.row-1][loc.col+1]
-	lowerLeft := g.data[loc.row+1][loc.col-1]
-	lowerRight := g.data[loc.row+1][loc.col+1]
+	upperLeft := g.at(loc.Row-1, loc.Col-1)
+	upperRight := g.at(loc.Row-1, loc.Col+1)
+	lowerLeft := g.at(loc.Row+1, loc.Col-1)
+	lowerRight := g.at(loc.Row+1, loc.Col+1)
 
 	if oppositeSandM(upperLeft, lowerRight) && oppositeSandM(lowerLeft, upperRight) {
 		return 1
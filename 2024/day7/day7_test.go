@@ -42,6 +42,14 @@ func TestPart1Example(t *testing.T) {
 	assert.Equal(t, 3749, answer)
 }
 
+func TestPart1_ZeroOperand(t *testing.T) {
+	// 5 is already past 3, but 5 * 0 + 3 comes back down to it
+	answer, err := day7.SolvePart1(strings.NewReader("3: 5 0 3"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, answer)
+}
+
 func TestPart2Example(t *testing.T) {
 	inFile := "./input.txt"
 	in, err := os.Open(inFile)
@@ -2,9 +2,10 @@
 package day8
 
 import (
-	"bufio"
 	"fmt"
 	"io"
+
+	"github.com/jstensland/advent-of-code/lib/grid"
 )
 
 func SolvePart1(in io.Reader) (int, error) {
@@ -58,8 +59,9 @@ func (l *Layout) AddAntinodes(locs []Location, part2 bool) {
 				locs = possibleAntiNodes(loc1, loc2)
 			}
 			for _, loc := range locs {
-				if !OffMap(loc, l.Width(), l.Height()) {
-					l.layout[loc.Row][loc.Col].antinode = true
+				if cell, ok := l.layout.Get(grid.Pos(loc)); ok {
+					cell.antinode = true
+					l.layout.Set(grid.Pos(loc), cell)
 				}
 			}
 		}
@@ -101,11 +103,9 @@ func possibleAntiNodes2(loc1, loc2 Location, width, height int) []Location {
 
 func (l *Layout) CountAntinodes() int {
 	total := 0
-	for _, row := range l.layout {
-		for _, cell := range row {
-			if cell.antinode {
-				total++
-			}
+	for _, cell := range l.layout.All() {
+		if cell.antinode {
+			total++
 		}
 	}
 	return total
@@ -119,7 +119,7 @@ type CellStatus struct {
 }
 
 type Layout struct {
-	layout   [][]CellStatus
+	layout   *grid.Grid[CellStatus]
 	antennas map[Antenna][]Location
 }
 
@@ -130,22 +130,16 @@ type Location struct {
 
 // String implements Stringer and allows printing of current state of the map
 func (l *Layout) String() string {
-	out := ""
-	for _, row := range l.layout {
-		for _, cell := range row {
-			if cell.antenna != 0 {
-				out += string(cell.antenna)
-			} else {
-				if cell.antinode {
-					out += "#" // match prompt on depiction of antinodes
-				} else {
-					out += "."
-				}
-			}
+	return l.layout.Render(func(cell CellStatus) rune {
+		switch {
+		case cell.antenna != 0:
+			return rune(cell.antenna)
+		case cell.antinode:
+			return '#' // match prompt on depiction of antinodes
+		default:
+			return '.'
 		}
-		out += "\n"
-	}
-	return out
+	})
 }
 
 func OffMap(loc Location, width, height int) bool {
@@ -153,41 +147,33 @@ func OffMap(loc Location, width, height int) bool {
 }
 
 func (l *Layout) Height() int {
-	return len(l.layout)
+	return l.layout.Height()
 }
 
 func (l *Layout) Width() int {
-	return len(l.layout[0])
+	return l.layout.Width()
 }
 
 // ParseInput loads the initial layout.
-// Collect antenna locations by type as you go
+// Collect antenna locations by type once the grid is loaded
 func ParseInput(in io.Reader) (*Layout, error) {
-	scanner := bufio.NewScanner(in)
-	var grid [][]CellStatus
-	antennasLocs := map[Antenna][]Location{}
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		gridRow := make([]CellStatus, len(line))
-		row := []rune(line)
-		for idx, charRune := range row {
-			if charRune == '.' {
-				// empty
-				gridRow[idx] = CellStatus{}
-			} else {
-				// everything other than '.' is an antenna
-				ant := Antenna(charRune)
-				gridRow[idx] = CellStatus{antenna: ant}
-				antennasLocs[ant] = append(antennasLocs[ant], Location{len(grid), idx})
-			}
+	layout, err := grid.Parse(in, func(char rune) (CellStatus, error) {
+		if char == '.' {
+			return CellStatus{}, nil // empty
 		}
-		grid = append(grid, gridRow)
+		// everything other than '.' is an antenna
+		return CellStatus{antenna: Antenna(char)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failure parsing layout: %w", err)
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failure scanning input: %w", err)
+	antennasLocs := map[Antenna][]Location{}
+	for pos, cell := range layout.All() {
+		if cell.antenna != 0 {
+			antennasLocs[cell.antenna] = append(antennasLocs[cell.antenna], Location(pos))
+		}
 	}
 
-	return &Layout{grid, antennasLocs}, nil
+	return &Layout{layout, antennasLocs}, nil
 }
@@ -2,10 +2,15 @@ module github.com/jstensland/advent-of-code/2024
 
 go 1.25
 
-require github.com/stretchr/testify v1.10.0
+require (
+	github.com/jstensland/advent-of-code/lib v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.11.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/jstensland/advent-of-code/lib => ../lib
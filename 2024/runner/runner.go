@@ -13,6 +13,7 @@ import (
 	"github.com/jstensland/advent-of-code/2024/day14"
 	"github.com/jstensland/advent-of-code/2024/day15"
 	"github.com/jstensland/advent-of-code/2024/day16"
+	"github.com/jstensland/advent-of-code/2024/day17"
 	"github.com/jstensland/advent-of-code/2024/day2"
 	"github.com/jstensland/advent-of-code/2024/day3"
 	"github.com/jstensland/advent-of-code/2024/day4"
@@ -75,6 +76,7 @@ func Run() error {
 		{"Day 15 Part 2", day15.SolvePart2, "./day15/input.txt"},
 		{"Day 16 Part 1", day16.SolvePart1, "./day16/input.txt"},
 		{"Day 16 Part 2", day16.SolvePart2, "./day16/input.txt"},
+		{"Day 17 Part 2", day17.SolvePart2, "./day17/input.txt"},
 	} {
 		err := RunIt(day.name, day.fn, day.in)
 		if err != nil {
@@ -2,24 +2,47 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"flag"
 	"fmt"
+	"go/format"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
 	"text/template"
 )
 
 const (
 	defaultDirPerms  = 0o750
 	defaultFilePerms = 0o640
+	templateDir      = "cmd/daygen/templates"
+	daysFile         = "runner/days.go"
+	defaultKind      = "blank"
+)
+
+// kinds are the supported puzzle shapes. Each has a directory of templates of the same name.
+//
+//nolint:gochecknoglobals // read only list of template directories
+var kinds = []string{defaultKind, "grid", "lines", "sections", "ints"}
+
+//nolint:gochecknoglobals // derived from templateDir
+var (
+	// sharedTestTemplate holds the part tests and benchmarks that every kind's test template includes.
+	sharedTestTemplate = filepath.Join(templateDir, "parts_test.go.tmpl")
+	daysTemplate       = filepath.Join(templateDir, "days.go.tmpl")
 )
 
 type templateData struct {
 	Day int
 }
 
+type daysData struct {
+	Days []int
+}
+
 func main() {
 	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -29,7 +52,9 @@ func main() {
 
 func run() error {
 	var dayStr string
+	var kind string
 	flag.StringVar(&dayStr, "day", "", "Day number (1-25)")
+	flag.StringVar(&kind, "kind", defaultKind, "Puzzle input shape ("+strings.Join(kinds, "|")+")")
 	flag.Parse()
 
 	if dayStr == "" {
@@ -41,61 +66,161 @@ func run() error {
 		return errors.New("invalid day number: must be between 1 and 25")
 	}
 
-	return generateDay(dayNum)
+	if !slices.Contains(kinds, kind) {
+		return fmt.Errorf("invalid kind %q: must be one of %s", kind, strings.Join(kinds, ", "))
+	}
+
+	return generateDay(dayNum, kind)
 }
 
-func generateDay(dayNum int) error {
+func generateDay(dayNum int, kind string) error {
 	dayDir := fmt.Sprintf("day%d", dayNum)
 
-	// Create directory
-	if err := os.MkdirAll(dayDir, defaultDirPerms); err != nil {
+	// Create directories
+	if err := os.MkdirAll(filepath.Join(dayDir, "testdata"), defaultDirPerms); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
 	data := templateData{Day: dayNum}
 
-	// Generate day.go
-	if err := generateFile(
-		"cmd/daygen/templates/day.go.tmpl",
-		filepath.Join(dayDir, fmt.Sprintf("day%d.go", dayNum)),
-		data,
-	); err != nil {
-		return err
+	for _, file := range []struct {
+		templateName string
+		outputPath   string
+	}{
+		{"day.go.tmpl", filepath.Join(dayDir, fmt.Sprintf("day%d.go", dayNum))},
+		{"day_test.go.tmpl", filepath.Join(dayDir, fmt.Sprintf("day%d_test.go", dayNum))},
+	} {
+		if err := generateFile(
+			[]string{filepath.Join(templateDir, kind, file.templateName), sharedTestTemplate},
+			file.outputPath,
+			data,
+		); err != nil {
+			return err
+		}
+	}
+
+	// Example inputs are pasted in from the prompt, so start them empty
+	for _, example := range []string{"example1.txt"} {
+		if err := createEmpty(filepath.Join(dayDir, "testdata", example)); err != nil {
+			return err
+		}
 	}
 
-	// Generate day_test.go
-	if err := generateFile(
-		"cmd/daygen/templates/day_test.go.tmpl",
-		filepath.Join(dayDir, fmt.Sprintf("day%d_test.go", dayNum)),
-		data,
-	); err != nil {
+	if err := registerDays(); err != nil {
 		return err
 	}
 
 	//nolint:forbidigo // print is good enough here
-	fmt.Printf("Generated boilerplate for day %d in %s/\n", dayNum, dayDir)
+	fmt.Printf("Generated %s boilerplate for day %d in %s/\n", kind, dayNum, dayDir)
 	return nil
 }
 
-func generateFile(templatePath, outputPath string, data templateData) error {
-	tmpl, err := template.ParseFiles(templatePath)
+// generateFile renders the first template into outputPath. Any other templates are parsed alongside it
+// so they can be used as shared blocks. Existing files are left untouched.
+func generateFile(templatePaths []string, outputPath string, data templateData) error {
+	tmpl, err := template.ParseFiles(templatePaths...)
 	if err != nil {
-		return fmt.Errorf("failed to parse template %s: %w", templatePath, err)
+		return fmt.Errorf("failed to parse templates %v: %w", templatePaths, err)
 	}
 
+	// render it all before creating the file, so a bad template leaves nothing behind
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return createNew(outputPath, buf.Bytes())
+}
+
+// createEmpty creates an empty file at path unless something is already there.
+func createEmpty(path string) error {
+	return createNew(path, nil)
+}
+
+// createNew writes content to a new file at path, unless something is already there. If the write
+// fails part way the file is removed, so the next run doesn't mistake it for one to keep.
+func createNew(path string, content []byte) error {
 	//nolint:gosec // output paths are hard coded
-	file, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, defaultFilePerms)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, defaultFilePerms)
 	if err != nil {
 		if os.IsExist(err) {
-			return fmt.Errorf("file %s already exists", outputPath)
+			skipped(path)
+			return nil
 		}
-		return fmt.Errorf("failed to create file %s: %w", outputPath, err)
+		return fmt.Errorf("failed to create file %s: %w", path, err)
+	}
+
+	_, err = file.Write(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to write %s: %w", path, err), os.Remove(path))
+	}
+	return nil
+}
+
+func skipped(path string) {
+	//nolint:forbidigo // print is good enough here
+	fmt.Printf("skipped %s: it already exists, so it was left as it is. Delete it to generate it again\n", path)
+}
+
+// registerDays regenerates the runner's list of days from the day directories present. The file is
+// only rewritten when the list of days changes.
+func registerDays() error {
+	days, err := findDays(".")
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.ParseFiles(daysTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template %s: %w", daysTemplate, err)
 	}
-	defer file.Close()
 
-	if err := tmpl.Execute(file, data); err != nil {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, daysData{Days: days}); err != nil {
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", daysFile, err)
+	}
 
+	existing, err := os.ReadFile(daysFile)
+	if err == nil && bytes.Equal(existing, src) {
+		return nil // already registered
+	}
+	if err := os.WriteFile(daysFile, src, defaultFilePerms); err != nil {
+		return fmt.Errorf("failed to write %s: %w", daysFile, err)
+	}
 	return nil
 }
+
+// findDays returns the sorted day numbers of every dayN directory under root containing a dayN.go.
+func findDays(root string) ([]int, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", root, err)
+	}
+
+	days := []int{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		numStr, ok := strings.CutPrefix(entry.Name(), "day")
+		if !ok {
+			continue
+		}
+		num, err := strconv.Atoi(numStr)
+		if err != nil {
+			continue // not a day directory
+		}
+		if _, err := os.Stat(filepath.Join(root, entry.Name(), entry.Name()+".go")); err != nil {
+			continue // nothing to register yet
+		}
+		days = append(days, num)
+	}
+	slices.Sort(days)
+	return days, nil
+}
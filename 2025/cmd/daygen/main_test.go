@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDays(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{
+		"day1/day1.go",
+		"day10/day10.go",
+		"day2/day2.go",
+		"day3/notes.txt", // no dayN.go yet
+		"dayx/dayx.go",   // not a day number
+		"runner/runner.go",
+	} {
+		path := filepath.Join(root, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), defaultDirPerms))
+		require.NoError(t, os.WriteFile(path, nil, defaultFilePerms))
+	}
+
+	days, err := findDays(root)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 10}, days)
+}
+
+func TestCreateNew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "day1.go")
+
+	require.NoError(t, createNew(path, []byte("first")))
+	require.NoError(t, createNew(path, []byte("second")), "an existing file is skipped")
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(got))
+}
+
+func TestGenerateFile_BadTemplateLeavesNothing(t *testing.T) {
+	dir := t.TempDir()
+	tmpl := filepath.Join(dir, "day.go.tmpl")
+	require.NoError(t, os.WriteFile(tmpl, []byte("package day{{.Missing}}"), defaultFilePerms))
+	out := filepath.Join(dir, "day1.go")
+
+	require.Error(t, generateFile([]string{tmpl}, out, templateData{Day: 1}))
+
+	_, err := os.Stat(out)
+	assert.ErrorIs(t, err, os.ErrNotExist, "no partial file to block the next run")
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+
+	"github.com/jstensland/advent-of-code/lib/numeric"
 )
 
 const (
@@ -39,11 +41,7 @@ func MoveDial(pos, num int) (int, int) {
 }
 
 func movePosition(pos, num int) int {
-	finalPosition := (pos + num) % positionsTotal
-	if finalPosition < 0 {
-		finalPosition += positionsTotal
-	}
-	return finalPosition
+	return numeric.Mod(pos+num, positionsTotal)
 }
 
 func moveZeros(pos, num int) int {
@@ -48,26 +48,21 @@ func InvalidIDs(inRange Range) ([]ID, error) {
 }
 
 func Part2(r io.Reader) (int, error) {
-	_ = r
-	return 0, nil
-}
+	ranges, err := ParseIn(r)
+	if err != nil {
+		return 0, err
+	}
+	ids := []ID{}
+	for _, ran := range ranges {
+		invalids, err := InvalidIDsV2(ran)
+		if err != nil {
+			return 0, fmt.Errorf("error determining invalid IDs: %w", err)
+		}
+		ids = append(ids, invalids...)
+	}
 
-// func Part2(r io.Reader) (int, error) {
-// 	ranges, err := ParseIn(r)
-// 	if err != nil {
-// 		return 0, err
-// 	}
-// 	ids := []ID{}
-// 	for _, ran := range ranges {
-// 		invalids, err := InvalidIDsV2(ran)
-// 		if err != nil {
-// 			return 0, fmt.Errorf("error determining invalid IDs: %w", err)
-// 		}
-// 		ids = append(ids, invalids...)
-// 	}
-//
-// 	return sumIDs(ids), nil
-// }
+	return sumIDs(ids), nil
+}
 
 // InvalidIDsV2 returns all invalid IDs for a given range.
 //
@@ -79,13 +74,17 @@ func Part2(r io.Reader) (int, error) {
 // 1111111 (1 seven times)
 // are all invalid IDs.
 func InvalidIDsV2(inRange Range) ([]ID, error) {
-	firstInvalid := findFirstV2(inRange.Start)
+	reps := make([]int, 0, len(inRange.End))
+	for rep := 2; rep <= len(inRange.End); rep++ {
+		reps = append(reps, rep)
+	}
+	ids, err := RepeatedIDs(inRange, reps...)
+	if err != nil {
+		return nil, err
+	}
 	out := []ID{}
-	end := inRange.End.AsInt()
-	candidate := firstInvalid
-	for ID(candidate).AsInt() <= end {
-		out = append(out, ID(candidate))
-		candidate = candidate.NextInvalid()
+	for id := range ids {
+		out = append(out, id)
 	}
 
 	return out, nil
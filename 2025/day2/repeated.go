@@ -0,0 +1,105 @@
+package day2
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// maxDigits is the longest ID that fits in an int.
+const maxDigits = 18
+
+var (
+	ErrIDTooLong   = fmt.Errorf("IDs can have at most %d digits", maxDigits)
+	ErrRepetitions = errors.New("a block must be repeated at least 2 times")
+)
+
+// RepeatedIDs yields every ID in the range that is a block of digits repeated one of reps
+// times, in increasing order and each only once. 222222 is 2 repeated 6 times, 22 repeated 3
+// times and 222 repeated twice, but comes out once.
+//
+// Nothing is scanned. A block of k digits repeated r times is the block times 1 followed by
+// r-1 lots of k-1 zeros and a 1, e.g. 123123 is 123 * 1001. So for each length of ID and each
+// repeat count that divides it, the blocks giving IDs in the range are a run of numbers worked
+// out by division. Those runs are merged in order.
+func RepeatedIDs(inRange Range, reps ...int) (iter.Seq[ID], error) {
+	if len(inRange.End) > maxDigits {
+		return nil, fmt.Errorf("%w: %v", ErrIDTooLong, inRange.End.AsInt())
+	}
+	for _, rep := range reps {
+		if rep < 2 { //nolint:mnd // one block on its own isn't repeated
+			return nil, fmt.Errorf("%w: %d", ErrRepetitions, rep)
+		}
+	}
+	reps = slices.Compact(slices.Sorted(slices.Values(reps)))
+	start, end := inRange.Start.AsInt(), inRange.End.AsInt()
+
+	return func(yield func(ID) bool) {
+		for length := max(len(inRange.Start), 1); length <= len(inRange.End); length++ {
+			runs := blockRuns(length, reps, max(start, pow10(length-1)), min(end, pow10(length)-1))
+			for id := range mergeRuns(runs) {
+				if !yield(toID(id)) {
+					return
+				}
+			}
+		}
+	}, nil
+}
+
+// blockRun is a run of repeated IDs of one length and repeat count: each block from next to
+// last, times step.
+type blockRun struct {
+	next, last, step int
+}
+
+// blockRuns works out, for each repeat count that divides length, the run of repeated IDs of
+// that length between lo and hi.
+func blockRuns(length int, reps []int, lo, hi int) []blockRun {
+	var runs []blockRun
+	for _, rep := range reps {
+		if length%rep != 0 {
+			continue
+		}
+		blockLen := length / rep
+		step := (pow10(length) - 1) / (pow10(blockLen) - 1) // 1001 for 3 digits twice
+		first := max((lo+step-1)/step, pow10(blockLen-1))   // no leading zero
+		last := min(hi/step, pow10(blockLen)-1)
+		if first <= last {
+			runs = append(runs, blockRun{next: first, last: last, step: step})
+		}
+	}
+	return runs
+}
+
+// mergeRuns yields the IDs of every run in order, skipping any found by more than one run.
+func mergeRuns(runs []blockRun) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		runs := slices.Clone(runs)
+		for len(runs) > 0 {
+			lowest := runs[0].next * runs[0].step
+			for _, run := range runs[1:] {
+				lowest = min(lowest, run.next*run.step)
+			}
+			if !yield(lowest) {
+				return
+			}
+			for idx := range runs {
+				if runs[idx].next*runs[idx].step == lowest {
+					runs[idx].next++
+				}
+			}
+			runs = slices.DeleteFunc(runs, func(run blockRun) bool { return run.next > run.last })
+		}
+	}
+}
+
+// pow10 is 10 to the power of n.
+func pow10(n int) int {
+	const base = 10
+	out := 1
+	for range n {
+		out *= base
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+package day2_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/2025/day2"
+)
+
+func toRange(t *testing.T, start, end int) day2.Range {
+	t.Helper()
+	ranges, err := day2.ParseIn(strings.NewReader(strconv.Itoa(start) + "-" + strconv.Itoa(end)))
+	require.NoError(t, err)
+	return ranges[0]
+}
+
+func repeatedInts(t *testing.T, inRange day2.Range, reps ...int) []int {
+	t.Helper()
+	ids, err := day2.RepeatedIDs(inRange, reps...)
+	require.NoError(t, err)
+	out := []int{}
+	for id := range ids {
+		out = append(out, id.AsInt())
+	}
+	return out
+}
+
+// isRepeated checks the digits of n, the slow way, for a block repeated one of reps times.
+func isRepeated(n int, reps []int) bool {
+	digits := strconv.Itoa(n)
+	for _, rep := range reps {
+		if len(digits)%rep == 0 && strings.Repeat(digits[:len(digits)/rep], rep) == digits {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRepeatedIDs(t *testing.T) {
+	inRange := toRange(t, 1, 2300)
+
+	assert.Equal(t, []int{
+		11, 22, 33, 44, 55, 66, 77, 88, 99,
+		111, 222, 333, 444, 555, 666, 777, 888, 999,
+		1010, 1111, 1212, 1313, 1414, 1515, 1616, 1717, 1818, 1919, 2020, 2121, 2222,
+	}, repeatedInts(t, inRange, 2, 3, 4))
+	assert.Equal(t, []int{111, 222, 333, 444, 555, 666, 777, 888, 999}, repeatedInts(t, inRange, 3))
+	assert.Equal(t, []int{1111, 2222}, repeatedInts(t, inRange, 4, 4), "repeat counts can repeat")
+	assert.Empty(t, repeatedInts(t, inRange))
+}
+
+func TestRepeatedIDs_MatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewPCG(2, 2025)) //nolint:gosec // repeatable test ranges
+
+	for range 300 {
+		start := rng.IntN(1_500_000)
+		end := start + rng.IntN(20_000)
+		reps := []int{}
+		for rep := 2; rep <= 7; rep++ {
+			if rng.IntN(2) == 0 {
+				reps = append(reps, rep)
+			}
+		}
+
+		want := []int{}
+		for n := start; n <= end; n++ {
+			if isRepeated(n, reps) {
+				want = append(want, n)
+			}
+		}
+
+		assert.Equal(t, want, repeatedInts(t, toRange(t, start, end), reps...), "%d-%d reps %v", start, end, reps)
+	}
+}
+
+func TestRepeatedIDs_18Digits(t *testing.T) {
+	reps := []int{2, 3, 6, 9, 18}
+
+	got := repeatedInts(t, toRange(t, 999_999_000_000_000_000, 999_999_999_999_999_999), reps...)
+
+	// 999999000 to 999999999 twice, which includes all 9s from 999999, 999, 99 and 9
+	assert.Len(t, got, 1000)
+	assert.True(t, slices.IsSorted(got))
+	assert.Equal(t, 999_999_000_999_999_000, got[0])
+	assert.Equal(t, 999_999_999_999_999_999, got[len(got)-1])
+}
+
+func TestRepeatedIDs_Stop(t *testing.T) {
+	ids, err := day2.RepeatedIDs(toRange(t, 1, 1_000_000), 2)
+	require.NoError(t, err)
+
+	var first day2.ID
+	for id := range ids {
+		first = id
+		break
+	}
+	assert.Equal(t, day2.ID{1, 1}, first)
+}
+
+func TestRepeatedIDs_Errors(t *testing.T) {
+	_, err := day2.RepeatedIDs(toRange(t, 1, 22), 1)
+	require.ErrorIs(t, err, day2.ErrRepetitions)
+
+	_, err = day2.RepeatedIDs(day2.Range{Start: day2.ID{1}, End: make(day2.ID, 19)}, 2)
+	require.ErrorIs(t, err, day2.ErrIDTooLong)
+}
@@ -2,13 +2,14 @@
 package day4
 
 import (
-	"bufio"
 	"fmt"
 	"io"
+
+	"github.com/jstensland/advent-of-code/lib/grid"
 )
 
 func Part1(r io.Reader) (int, error) {
-	grid, err := ParseIn(r)
+	paper, err := ParseIn(r)
 	if err != nil {
 		return 0, err
 	}
@@ -21,14 +22,14 @@ func Part1(r io.Reader) (int, error) {
 	// Option 2
 	// - for each cell, make a func that counts papers in adjacent cells
 	movable := 0
-	for pos := range grid.positions() {
-		movable += grid.CanMove(pos)
+	for pos := range paper.Positions() {
+		movable += paper.CanMove(pos)
 	}
 	return movable, nil
 }
 
 func Part2(r io.Reader) (int, error) {
-	grid, err := ParseIn(r)
+	paper, err := ParseIn(r)
 	if err != nil {
 		return 0, err
 	}
@@ -37,48 +38,20 @@ func Part2(r io.Reader) (int, error) {
 
 	// Option 2: Iterate once, but each time a cell is removed, check how it affected all adjacent cells
 	removed := 0
-	for pos := range grid.positions() {
-		removed += grid.TryRemoval(pos)
+	for pos := range paper.Positions() {
+		removed += paper.TryRemoval(pos)
 	}
 
 	return removed, nil
 }
 
 func ParseIn(r io.Reader) (Grid, error) {
-	scanner := bufio.NewScanner(r)
-	var cells [][]CellState
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) == 0 {
-			continue
-		}
-
-		row := make([]CellState, len(line))
-		for i, char := range line {
-			switch char {
-			case '@':
-				row[i] = PaperRoll
-			case '.':
-				row[i] = Empty
-			}
-		}
-		cells = append(cells, row)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return Grid{}, fmt.Errorf("scanner error: %w", err)
-	}
-
-	height := len(cells)
-	width := 0
-	if height > 0 {
-		width = len(cells[0])
+	cells, err := grid.Parse(r, grid.Mapping(map[rune]CellState{
+		'@': PaperRoll,
+		'.': Empty,
+	}))
+	if err != nil {
+		return Grid{}, fmt.Errorf("failed to parse grid: %w", err)
 	}
-
-	return Grid{
-		Cells:  cells,
-		width:  width,
-		height: height,
-	}, nil
+	return Grid{cells}, nil
 }
@@ -36,13 +36,13 @@ func TestParseIn(t *testing.T) {
 	assert.Equal(t, 10, grid.Height(), "expected height of 10")
 
 	// Check some sample cells
-	assert.Equal(t, day4.Empty, grid.Cells[0][0], "position (0,0) should be Empty")
-	assert.Equal(t, day4.Empty, grid.Cells[0][1], "position (0,1) should be Empty")
-	assert.Equal(t, day4.PaperRoll, grid.Cells[0][2], "position (0,2) should be PaperRoll")
-	assert.Equal(t, day4.PaperRoll, grid.Cells[1][0], "position (1,0) should be PaperRoll")
-	assert.Equal(t, day4.Empty, grid.Cells[1][3], "position (1,3) should be Empty")
-	assert.Equal(t, day4.PaperRoll, grid.Cells[9][0], "position (9,0) should be PaperRoll")
-	assert.Equal(t, day4.Empty, grid.Cells[9][9], "position (9,9) should be Empty")
+	assert.Equal(t, day4.Empty, grid.Cell(0, 0), "position (0,0) should be Empty")
+	assert.Equal(t, day4.Empty, grid.Cell(0, 1), "position (0,1) should be Empty")
+	assert.Equal(t, day4.PaperRoll, grid.Cell(0, 2), "position (0,2) should be PaperRoll")
+	assert.Equal(t, day4.PaperRoll, grid.Cell(1, 0), "position (1,0) should be PaperRoll")
+	assert.Equal(t, day4.Empty, grid.Cell(1, 3), "position (1,3) should be Empty")
+	assert.Equal(t, day4.PaperRoll, grid.Cell(9, 0), "position (9,0) should be PaperRoll")
+	assert.Equal(t, day4.Empty, grid.Cell(9, 9), "position (9,9) should be Empty")
 }
 
 func TestPart1(t *testing.T) {
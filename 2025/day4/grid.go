@@ -1,6 +1,6 @@
 package day4
 
-import "iter"
+import "github.com/jstensland/advent-of-code/lib/grid"
 
 // CellState represents the state of a location in the grid.
 type CellState int
@@ -12,28 +12,23 @@ const (
 
 // Grid represents the parsed input grid where each location can either be a paper roll or empty.
 type Grid struct {
-	Cells  [][]CellState
-	width  int
-	height int
+	*grid.Grid[CellState]
 }
 
-func (g *Grid) Width() int  { return g.width }
-func (g *Grid) Height() int { return g.height }
-
-type position struct {
-	row int
-	col int
+// Cell returns the state of the cell at the given row and column.
+func (g Grid) Cell(row, col int) CellState {
+	return g.At(grid.Pos{Row: row, Col: col})
 }
 
 // CanMove returns 1 if it's paper and has fewer than 4 other rolls around it. Otherwise, it returns 0.
-func (g *Grid) CanMove(pos position) int {
-	if g.Cells[pos.row][pos.col] != PaperRoll {
+func (g Grid) CanMove(pos grid.Pos) int {
+	if g.At(pos) != PaperRoll {
 		return 0
 	}
 	total := 0
 
-	for pos := range g.surroundingPositions(pos) {
-		if g.Cells[pos.row][pos.col] == PaperRoll {
+	for neighbor := range g.Neighbors8(pos) {
+		if g.At(neighbor) == PaperRoll {
 			total++
 		}
 	}
@@ -44,44 +39,14 @@ func (g *Grid) CanMove(pos position) int {
 	return 0
 }
 
-func (g *Grid) TryRemoval(pos position) int {
+func (g Grid) TryRemoval(pos grid.Pos) int {
 	if g.CanMove(pos) == 0 {
 		return 0
 	}
-	g.Cells[pos.row][pos.col] = Empty
+	g.Set(pos, Empty)
 	removed := 1
-	for neighbor := range g.surroundingPositions(pos) {
+	for neighbor := range g.Neighbors8(pos) {
 		removed += g.TryRemoval(neighbor)
 	}
 	return removed
 }
-
-func (g *Grid) positions() iter.Seq[position] {
-	return func(yield func(r position) bool) {
-		for row := range g.height {
-			for col := range g.width {
-				if !yield(position{row, col}) {
-					return
-				}
-			}
-		}
-	}
-}
-
-// surroundingPositions takes a position on the grid, and returns a slice of the surrounding positions
-// it is aware of the edges of the grid.
-func (g *Grid) surroundingPositions(pos position) iter.Seq[position] {
-	return func(yield func(r position) bool) {
-		for i := pos.row - 1; i <= pos.row+1; i++ {
-			for j := pos.col - 1; j <= pos.col+1; j++ {
-				if i >= 0 && i < g.height && // row on the grid
-					j >= 0 && j < g.width && // col on the grid
-					(i != pos.row || j != pos.col) { // not the position itself
-					if !yield(position{i, j}) {
-						return
-					}
-				}
-			}
-		}
-	}
-}
@@ -2,11 +2,11 @@
 package day5
 
 import (
-	"bufio"
 	"fmt"
 	"io"
-	"strconv"
-	"strings"
+
+	"github.com/jstensland/advent-of-code/lib/interval"
+	"github.com/jstensland/advent-of-code/lib/parse"
 )
 
 func Part1(r io.Reader) (int, error) {
@@ -14,7 +14,7 @@ func Part1(r io.Reader) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	return countFresh(CombineRanges(spans), items), nil
+	return countFresh(interval.New(spans...), items), nil
 }
 
 func Part2(r io.Reader) (int, error) {
@@ -22,49 +22,25 @@ func Part2(r io.Reader) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	return countIDs(CombineRanges(spans)), nil
+	return interval.New(spans...).Len(), nil
 }
 
+// ParseIn reads the fresh ranges, then after a blank line, the available items.
 func ParseIn(r io.Reader) ([]Span, []int, error) {
-	scanner := bufio.NewScanner(r)
-	ranges := []Span{}
-
-	// process the first section
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			break
-		}
-
-		const spanSegments = 2
-		pieces := strings.Split(line, "-")
-		if len(pieces) != spanSegments {
-			return nil, nil, fmt.Errorf("unexpected range line: %v", line)
-		}
-		start, err := strconv.Atoi(pieces[0])
-		if err != nil {
-			return nil, nil, fmt.Errorf("unexpected conversion error: %w", err)
-		}
-		end, err := strconv.Atoi(pieces[1])
-		if err != nil {
-			return nil, nil, fmt.Errorf("unexpected conversion error: %w", err)
-		}
-		ranges = append(ranges, Span{Start: start, End: end})
+	const sections = 2 // ranges, then items
+	secs, err := parse.SectionsN(r, sections)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// process the items
-	items := []int{}
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		item, err := strconv.Atoi(line)
-		if err != nil {
-			return nil, nil, fmt.Errorf("unexpected conversion error: %w", err)
-		}
-		items = append(items, item)
+	ranges, err := parse.Map(secs[0], parse.MustRecord[Span](`^\s*(\d+)-(\d+)\s*$`).Decode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unexpected range line: %w", err)
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, nil, fmt.Errorf("scanner error on input: %w", err)
+	items, err := parse.Map(secs[1], parse.Value[int])
+	if err != nil {
+		return nil, nil, fmt.Errorf("unexpected item line: %w", err)
 	}
 
 	return ranges, items, nil
@@ -11,7 +11,7 @@ func Part1(r io.Reader) (int, error) {
 		return 0, err
 	}
 
-	for range grid.height {
+	for range grid.Height() {
 		grid.Progress()
 	}
 
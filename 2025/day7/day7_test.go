@@ -2,6 +2,7 @@ package day7_test
 
 import (
 	"bytes"
+	"image/gif"
 	"os"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/jstensland/advent-of-code/2025/day7"
 	"github.com/jstensland/advent-of-code/2025/runner"
+	"github.com/jstensland/advent-of-code/lib/render"
 )
 
 var _ runner.Solver = day7.Part1
@@ -85,3 +87,42 @@ func TestPart2_Example1(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, answer, result)
 }
+
+func TestPart2_FreshMemoPerInput(t *testing.T) {
+	straight, err := day7.Part2(bytes.NewReader([]byte(".S.\n...\n...")))
+	require.NoError(t, err)
+	assert.Equal(t, 1, straight)
+
+	// same shape, so a shared cache would answer with the grid above
+	split, err := day7.Part2(bytes.NewReader([]byte(".S.\n.^.\n...")))
+	require.NoError(t, err)
+	assert.Equal(t, 2, split)
+}
+
+func TestTimelineStats(t *testing.T) {
+	grid, err := day7.ParseIn(bytes.NewReader([]byte(example1())))
+	require.NoError(t, err)
+	startRow, startCol := grid.Start()
+
+	stats := grid.TimelineStats(startRow+1, startCol)
+
+	assert.Positive(t, stats.Hits, "paths rejoin, so some timelines are reused")
+	assert.Equal(t, 40, grid.ProgressTimeline(startRow+1, startCol))
+}
+
+func TestRecordBeams(t *testing.T) {
+	grid, err := day7.ParseIn(bytes.NewReader([]byte(example1())))
+	require.NoError(t, err)
+	rec, err := render.NewRecorder(day7.Palette(), 4, 10)
+	require.NoError(t, err)
+
+	frames := rec.Record(grid.Snapshot, grid.Step, 100)
+
+	assert.Equal(t, grid.Height(), frames, "a frame per row")
+	assert.Equal(t, 21, grid.SplitCount())
+	var buf bytes.Buffer
+	require.NoError(t, rec.WriteGIF(&buf))
+	anim, err := gif.DecodeAll(&buf)
+	require.NoError(t, err)
+	assert.Len(t, anim.Image, frames)
+}
@@ -0,0 +1,12 @@
+package day7
+
+import (
+	"github.com/jstensland/advent-of-code/lib/grid"
+	"github.com/jstensland/advent-of-code/lib/memo"
+)
+
+// TimelineStats exposes how well the timeline memo did for tests
+func (g *Grid) TimelineStats(rowIdx, colIdx int) memo.Stats {
+	_, stats := g.timelines(grid.Pos{Row: rowIdx, Col: colIdx})
+	return stats
+}
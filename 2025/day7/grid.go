@@ -1,11 +1,13 @@
 package day7
 
 import (
-	"bufio"
 	"fmt"
+	"image/color"
 	"io"
-	"strconv"
-	"strings"
+
+	"github.com/jstensland/advent-of-code/lib/grid"
+	"github.com/jstensland/advent-of-code/lib/memo"
+	"github.com/jstensland/advent-of-code/lib/render"
 )
 
 // CellState represents the state of a location in the grid.
@@ -20,22 +22,21 @@ const (
 
 // Grid represents the parsed input, and its evolution.
 type Grid struct {
-	grid       [][]CellState
-	width      int
-	height     int
+	cells      *grid.Grid[CellState]
 	iteration  int
 	splitCount int
 }
 
-func (g *Grid) Width() int  { return g.width }
-func (g *Grid) Height() int { return g.height }
-func (g *Grid) String() string {
-	var sb strings.Builder
-	for _, row := range g.grid {
-		sb.WriteString(string(row))
-		sb.WriteByte('\n')
-	}
-	return sb.String()
+func (g *Grid) Width() int     { return g.cells.Width() }
+func (g *Grid) Height() int    { return g.cells.Height() }
+func (g *Grid) String() string { return g.cells.String() }
+
+func (g *Grid) at(row, col int) CellState {
+	return g.cells.At(grid.Pos{Row: row, Col: col})
+}
+
+func (g *Grid) set(row, col int, cell CellState) {
+	g.cells.Set(grid.Pos{Row: row, Col: col}, cell)
 }
 
 func (g *Grid) SplitCount() int {
@@ -43,73 +44,94 @@ func (g *Grid) SplitCount() int {
 }
 
 func ParseIn(r io.Reader) (*Grid, error) {
-	cells := [][]CellState{}
-	scanner := bufio.NewScanner(r)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		cells = append(cells, []CellState(line))
+	cells, err := grid.Parse(r, grid.Mapping(map[rune]CellState{
+		rune(Start):    Start,
+		rune(Empty):    Empty,
+		rune(Splitter): Splitter,
+		rune(Beam):     Beam,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifold: %w", err)
 	}
 
 	return &Grid{
-		grid:      cells,
-		width:     len(cells[0]),
-		height:    len(cells),
+		cells:     cells,
 		iteration: 0,
 	}, nil
 }
 
-// cache for memoization
-//
-//nolint:gochecknoglobals // cache for memoization
-var cache = map[string]int{}
-
+// ProgressTimeline counts the timelines a particle entering at the given location ends up in.
+// Every call gets its own memo, so nothing carries over between grids.
 func (g *Grid) ProgressTimeline(rowIdx, colIdx int) int {
-	key := strconv.Itoa(rowIdx) + "_" + strconv.Itoa(colIdx)
-	if val, ok := cache[key]; ok {
-		return val
-	}
-	// base cases
-	// if we're out of rows, return 1
-	if rowIdx == g.height-1 {
-		return 1
-	}
-	// column idx shouldn't be able to go off the grid. skipping condition
+	count, _ := g.timelines(grid.Pos{Row: rowIdx, Col: colIdx})
+	return count
+}
 
-	if g.grid[rowIdx][colIdx] == Empty {
-		// add nothing, no split, just keep going
-		answer := g.ProgressTimeline(rowIdx+1, colIdx)
-		cache[key] = answer
-		return answer
-	}
+// timelines counts the timelines from pos, and returns the memo stats for inspection.
+func (g *Grid) timelines(pos grid.Pos) (int, memo.Stats) {
+	count, m := memo.Recursive(func(recurse func(grid.Pos) int, pos grid.Pos) int {
+		// base cases
+		// if we're out of rows, return 1
+		if pos.Row == g.Height()-1 {
+			return 1
+		}
+		// column idx shouldn't be able to go off the grid. skipping condition
 
-	if g.grid[rowIdx][colIdx] == Splitter {
-		// return the addition of the add the number of possibility on the right path to
-		// the number of possibilities on the left
-		lanswer := g.ProgressTimeline(rowIdx+1, colIdx-1)
-		cache[key] = lanswer
-		ranswer := g.ProgressTimeline(rowIdx+1, colIdx+1)
-		cache[key] = ranswer
-		return lanswer + ranswer
-	}
-	panic(fmt.Sprintf("AHHH what did I hit?! %v", g.grid[rowIdx][colIdx]))
+		if g.at(pos.Row, pos.Col) == Empty {
+			// add nothing, no split, just keep going
+			return recurse(grid.Pos{Row: pos.Row + 1, Col: pos.Col})
+		}
+
+		if g.at(pos.Row, pos.Col) == Splitter {
+			// return the addition of the add the number of possibility on the right path to
+			// the number of possibilities on the left
+			return recurse(grid.Pos{Row: pos.Row + 1, Col: pos.Col - 1}) +
+				recurse(grid.Pos{Row: pos.Row + 1, Col: pos.Col + 1})
+		}
+		panic(fmt.Sprintf("AHHH what did I hit?! %v", g.at(pos.Row, pos.Col)))
+	})
+	return count(pos), m.Stats()
 }
 
 func (g *Grid) Start() (int, int) {
-	for idx, cell := range g.grid[0] {
-		if cell == Start {
-			return 0, idx
-		}
+	if pos, ok := grid.Find(g.cells, Start); ok && pos.Row == 0 {
+		return pos.Row, pos.Col
 	}
 	panic("no start in the first row!")
 }
 
+// Step progresses the beams one row, returning false once they reach the bottom. For rendering.
+func (g *Grid) Step() bool {
+	if g.iteration == g.Height()-1 {
+		return false
+	}
+	g.Progress()
+	return true
+}
+
+// Snapshot is a copy of the manifold, for rendering.
+func (g *Grid) Snapshot() *grid.Grid[CellState] {
+	return g.cells.Clone()
+}
+
+// Palette colours a manifold snapshot for rendering.
+func Palette() render.Palette[CellState] {
+	return render.Palette[CellState]{
+		Colors: map[CellState]color.Color{
+			Start:    color.RGBA{R: 250, G: 250, B: 250, A: 255},
+			Empty:    color.RGBA{R: 15, G: 15, B: 35, A: 255},
+			Splitter: color.RGBA{R: 230, G: 120, B: 30, A: 255},
+			Beam:     color.RGBA{R: 60, G: 200, B: 250, A: 255},
+		},
+	}
+}
+
 func (g *Grid) Progress() {
-	if g.iteration == g.height-1 {
+	if g.iteration == g.Height()-1 {
 		return
 	}
-	for colIdx, cell := range g.grid[g.iteration] {
-		switch cell {
+	for colIdx := range g.Width() {
+		switch g.at(g.iteration, colIdx) {
 		case Start, Beam:
 			g.advance(colIdx)
 		case Splitter:
@@ -121,16 +143,13 @@ func (g *Grid) Progress() {
 }
 
 func (g *Grid) advance(col int) {
-	switch g.grid[g.iteration+1][col] {
+	switch g.at(g.iteration+1, col) {
 	case Empty, Start, Beam:
-		g.grid[g.iteration+1][col] = Beam
+		g.set(g.iteration+1, col, Beam)
 	case Splitter:
-		if col-1 >= 0 {
-			g.grid[g.iteration+1][col-1] = Beam
-		}
-		if col+1 < g.width {
-			g.grid[g.iteration+1][col+1] = Beam
-		}
+		// set ignores beams that would go off the side of the grid
+		g.set(g.iteration+1, col-1, Beam)
+		g.set(g.iteration+1, col+1, Beam)
 		g.splitCount++
 	default:
 		panic("uh oh, how did I get here?")
@@ -138,5 +157,5 @@ func (g *Grid) advance(col int) {
 }
 
 func (g *Grid) clear(col int) {
-	g.grid[g.iteration+1][col] = Empty
+	g.set(g.iteration+1, col, Empty)
 }
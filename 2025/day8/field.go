@@ -1,15 +1,15 @@
 package day8
 
 import (
-	"maps"
 	"slices"
+
+	"github.com/jstensland/advent-of-code/lib/unionfind"
 )
 
 type Field struct {
-	points     []Point
-	pairs      []Distance
-	sets       map[string]*Set
-	pointToSet map[Point]*Set
+	points   []Point
+	pairs    []Distance
+	circuits *unionfind.UnionFind[Point]
 }
 
 func NewField(points []Point) *Field {
@@ -35,20 +35,11 @@ func NewField(points []Point) *Field {
 		}
 	})
 
-	out := &Field{
-		points:     points,
-		pairs:      pairs,
-		sets:       map[string]*Set{},
-		pointToSet: map[Point]*Set{},
-	}
-
-	// Put each point in it's own set
-	for _, point := range points {
-		set := NewSet([]Point{point})
-		out.Set(&set)
+	return &Field{
+		points:   points,
+		pairs:    pairs,
+		circuits: unionfind.New(points...), // each point starts in its own circuit
 	}
-
-	return out
 }
 
 // Pairs exposes the parsed pairs. It should probably make a copy instead
@@ -79,32 +70,32 @@ func (f *Field) ConnectAll() Distance {
 	return shortestDistance
 }
 
-func (f *Field) Del(s *Set) {
-	delete(f.sets, s.String())
-	for _, p := range s.Points() {
-		delete(f.pointToSet, p)
+// FindSet returns the circuit the point is connected to, or nil if it isn't in the field.
+func (f *Field) FindSet(p Point) *Set {
+	if !f.circuits.Contains(p) {
+		return nil
 	}
-}
-
-func (f *Field) Set(s *Set) {
-	f.sets[s.String()] = s
-	for _, p := range s.Points() {
-		f.pointToSet[p] = s
+	set := NewSet(nil)
+	for _, other := range f.points {
+		if f.circuits.Connected(p, other) {
+			set.Add(other)
+		}
 	}
-}
-
-func (f *Field) FindSet(p Point) *Set {
-	return f.pointToSet[p]
+	return &set
 }
 
 func (f *Field) NumSets() int {
-	return len(f.sets)
+	return f.circuits.Count()
 }
 
 // SortedSets returns the sets sorted in reverse order by size.
 func (f *Field) SortedSets() []*Set {
-	sortedSets := slices.Collect(maps.Values(f.sets))
-	slices.SortFunc(sortedSets, func(a, b *Set) int {
+	sortedSets := []*Set{}
+	for _, circuit := range f.circuits.Components() {
+		set := NewSet(circuit)
+		sortedSets = append(sortedSets, &set)
+	}
+	slices.SortStableFunc(sortedSets, func(a, b *Set) int {
 		return b.Size() - a.Size() // reverse sort
 	})
 	return sortedSets
@@ -112,27 +103,14 @@ func (f *Field) SortedSets() []*Set {
 
 // MultiplyLargest returns the sizes of the largest num sets.
 func (f *Field) MultiplyLargest(num int) int {
-	largest := f.SortedSets()[:num]
 	out := 1
-	for _, value := range largest {
-		out *= value.Size()
+	for _, size := range f.circuits.Sizes()[:num] {
+		out *= size
 	}
 	return out
 }
 
-// Connect mutates the field by connecting the num of closets points.
+// connect mutates the field by joining the circuits of the two points.
 func (f *Field) connect(ps [2]Point) {
-	// find the set for p1 and p2
-	p1, p2 := ps[0], ps[1]
-	s1 := f.FindSet(p1)
-	s2 := f.FindSet(p2) // TODO: some how on the second iteration of the test, the p2 is not finding a set already!
-
-	// TODO: I could do better than this with a method.... s1.Equals(s2)
-	if s1.String() == s2.String() {
-		return
-	}
-	s3 := s1.Merge(s2)
-	f.Del(s1)
-	f.Del(s2)
-	f.Set(s3)
+	f.circuits.Union(ps[0], ps[1])
 }
@@ -3,9 +3,9 @@ package day8
 import (
 	"maps"
 	"slices"
-	"strings"
 )
 
+// Set is a group of points connected into one circuit.
 type Set struct {
 	points map[Point]bool
 }
@@ -22,14 +22,6 @@ func (s *Set) Add(p Point) {
 	s.points[p] = true
 }
 
-func (s *Set) Merge(s2 *Set) *Set {
-	newSet := NewSet(s.Points())
-	for p := range s2.points {
-		newSet.Add(p)
-	}
-	return &newSet
-}
-
 // Points returns the points in the set as a slice. Order is not maintained.
 func (s *Set) Points() []Point {
 	return slices.Collect(maps.Keys(s.points))
@@ -38,16 +30,3 @@ func (s *Set) Points() []Point {
 func (s *Set) Size() int {
 	return len(s.points)
 }
-
-func (s *Set) String() string {
-	points := slices.Collect(maps.Keys(s.points))
-	slices.SortFunc(points, comparePoints)
-	var sb strings.Builder
-	for i, p := range points {
-		if i > 0 {
-			sb.WriteString("-")
-		}
-		sb.WriteString(p.String())
-	}
-	return sb.String()
-}
@@ -3,7 +3,6 @@ package day9
 
 import (
 	"io"
-	"slices"
 )
 
 func Part1(r io.Reader) (int, error) {
@@ -12,28 +11,17 @@ func Part1(r io.Reader) (int, error) {
 		return 0, err
 	}
 
-	minX, maxX, minY, maxY := FindExtremes(in)
-	// Sort the points by distance from the center
-	slices.SortFunc(in, FurtherFromCenter(minX, maxX, minY, maxY))
-	pool := NewCandidatePool()
-
-	for _, p := range in {
-		// if the biggest square from the current point to a corner is SMALLER than our current biggest, then quit
-		if biggestToCorner(minX, maxX, minY, maxY, p) < pool.Biggest().Area() {
-			break
-		}
-		pool.Add(p)
-	}
-	return pool.Biggest().Area(), nil
+	return BiggestSquare(in).Area(), nil
 }
 
 func Part2(r io.Reader) (int, error) {
-	_, err := ParseIn(r)
+	in, err := ParseIn(r)
 	if err != nil {
 		return 0, err
 	}
-	// TODO: solve part 2
-	answer := 0
-
-	return answer, nil
+	polygon, err := NewPolygon(in)
+	if err != nil {
+		return 0, err
+	}
+	return polygon.Biggest().Area(), nil
 }
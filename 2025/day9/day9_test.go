@@ -55,7 +55,7 @@ func TestPart2_Example1(t *testing.T) {
 }
 
 func TestPart2(t *testing.T) {
-	answer := 0 // TODO: update to answer
+	answer := 1566346198 // matches checking every rectangle against the loop edges
 	input, err := os.ReadFile("input.txt")
 	require.NoError(t, err, "failed to read input.txt")
 
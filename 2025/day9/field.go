@@ -1,7 +1,10 @@
 package day9
 
 import (
+	"cmp"
 	"iter"
+
+	"github.com/jstensland/advent-of-code/lib/geom"
 )
 
 func FindExtremes(points []Point) (int, int, int, int) {
@@ -18,40 +21,20 @@ func FindExtremes(points []Point) (int, int, int, int) {
 	return minX, maxX, minY, maxY
 }
 
-type Direction int
-
-const (
-	Up Direction = iota
-	Right
-	Down
-	Left
-)
-
-func Turn(d Direction) Direction {
-	const numDirs = 4
-	return (d + 1) % numDirs
-}
-
 // FurtherFromCenter returns a sort function that puts the points further from the center of the provided min/max
 // values first.
+//
+// The center can be half way between two tiles, so the distances are worked out on doubled coordinates, and compared
+// squared, keeping it all in integers.
 func FurtherFromCenter(minX, maxX, minY, maxY int) func(p1, p2 Point) int {
-	// TODO: this isn't quite the middle... integer division
-	const numNums = 2
-	center := Point{
-		X: (minX + maxX) / numNums,
-		Y: (minY + maxY) / numNums,
+	fromCenter := func(p Point) int {
+		dx := 2*p.X - (minX + maxX) //nolint:mnd // doubled coordinates
+		dy := 2*p.Y - (minY + maxY) //nolint:mnd // doubled coordinates
+		return dx*dx + dy*dy
 	}
-
 	return func(p1, p2 Point) int {
-		// should be negative when p1 < p2 for forward sort
-		// we want furthest first
-		// negative when p2 is closer to center
-		p2FromCenter := distance(p2, center)
-		p1FromCenter := distance(p1, center)
-		if p2FromCenter < p1FromCenter {
-			return -1
-		}
-		return 1
+		// furthest first
+		return cmp.Compare(fromCenter(p2), fromCenter(p1))
 	}
 }
 
@@ -61,29 +44,29 @@ func FurtherFromCenter(minX, maxX, minY, maxY int) func(p1, p2 Point) int {
 //nolint:cyclop // skipping refactor as not used
 func SpiralIn(minX, maxX, minY, maxY int) iter.Seq[Point] {
 	x, y := minX, minY
-	facing := Up
+	facing := geom.Up
 	return func(yield func(r Point) bool) {
 		for minX < maxX && minY < maxY {
 			if !yield(Point{x, y}) {
 				return
 			}
 			switch facing {
-			case Up:
+			case geom.Up:
 				if y < maxY {
 					y++
 					continue
 				}
-			case Right:
+			case geom.Right:
 				if x < maxX {
 					x++
 					continue
 				}
-			case Down:
+			case geom.Down:
 				if y > minY {
 					y--
 					continue
 				}
-			case Left:
+			case geom.Left:
 				if x > minX {
 					x--
 					continue
@@ -96,15 +79,15 @@ func SpiralIn(minX, maxX, minY, maxY int) iter.Seq[Point] {
 				y++
 				x++
 			}
-			facing = Turn(facing)
+			facing = facing.Clockwise()
 			switch facing {
-			case Up:
+			case geom.Up:
 				y++
-			case Right:
+			case geom.Right:
 				x++
-			case Down:
+			case geom.Down:
 				y--
-			case Left:
+			case geom.Left:
 				x--
 			}
 		}
@@ -130,31 +113,3 @@ func (s Square) Area() int {
 	height := max(s.Flag1.Y, s.Flag2.Y) - min(s.Flag1.Y, s.Flag2.Y) + 1
 	return width * height
 }
-
-type CandidatePool struct {
-	biggest Square
-	points  map[Point]bool
-	combos  []Square
-}
-
-func NewCandidatePool() *CandidatePool {
-	return &CandidatePool{
-		biggest: Square{Flag1: Point{}, Flag2: Point{}},
-		points:  map[Point]bool{},
-		combos:  []Square{},
-	}
-}
-
-func (cp *CandidatePool) Add(flag Point) {
-	for point := range cp.points {
-		newSquare := Square{point, flag}
-		// fmt.Printf("new square added with point %v and %v with area %v\n", flag, point, newSquare.Area())
-		if newSquare.Area() > cp.biggest.Area() {
-			cp.biggest = newSquare
-		}
-		cp.combos = append(cp.combos, newSquare)
-	}
-	cp.points[flag] = true
-}
-
-func (cp *CandidatePool) Biggest() Square { return cp.biggest }
@@ -8,40 +8,6 @@ import (
 	"github.com/jstensland/advent-of-code/2025/day9"
 )
 
-func TestTurn(t *testing.T) {
-	testCases := []struct {
-		desc    string
-		initDir day9.Direction
-		endDir  day9.Direction
-	}{
-		{
-			desc:    "start Up",
-			initDir: day9.Up,
-			endDir:  day9.Right,
-		},
-		{
-			desc:    "start Right",
-			initDir: day9.Right,
-			endDir:  day9.Down,
-		},
-		{
-			desc:    "start Down",
-			initDir: day9.Down,
-			endDir:  day9.Left,
-		},
-		{
-			desc:    "start Left",
-			initDir: day9.Left,
-			endDir:  day9.Up,
-		},
-	}
-	for _, tc := range testCases {
-		t.Run(tc.desc, func(t *testing.T) {
-			assert.Equal(t, tc.endDir, day9.Turn(tc.initDir))
-		})
-	}
-}
-
 func TestSpiralIn(t *testing.T) {
 	output := []day9.Point{}
 	for p := range day9.SpiralIn(0, 5, 0, 5) {
@@ -101,3 +67,10 @@ func TestDistanceFromCenter(t *testing.T) {
 	assert.Equal(t, -1, cmp(near, center), "near point should come before center point")
 	assert.Equal(t, 1, cmp(near, far), "near point should come after far point")
 }
+
+func TestDistanceFromCenter_HalfWay(t *testing.T) {
+	cmp := day9.FurtherFromCenter(0, 3, 0, 1) // center is (1.5, 0.5)
+
+	assert.Equal(t, 0, cmp(day9.Point{X: 1, Y: 0}, day9.Point{X: 2, Y: 1}), "both are half a tile each way")
+	assert.Equal(t, -1, cmp(day9.Point{X: 0, Y: 0}, day9.Point{X: 1, Y: 1}), "the corner is further")
+}
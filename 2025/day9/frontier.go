@@ -0,0 +1,56 @@
+package day9
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Frontier is the staircase of red tiles furthest towards one corner of the floor: those with no
+// other red tile at least as far that way in both x and y. A sign of -1 flips an axis, so
+// Frontier(points, 1, 1) is the tiles towards the lowest x and y.
+//
+// It sweeps the tiles in order of x, keeping any that beat the best y seen so far.
+func Frontier(points []Point, signX, signY int) []Point {
+	sorted := slices.Clone(points)
+	slices.SortFunc(sorted, func(p1, p2 Point) int {
+		return cmp.Or(cmp.Compare(signX*p1.X, signX*p2.X), cmp.Compare(signY*p1.Y, signY*p2.Y))
+	})
+
+	out := sorted[:0]
+	for idx, p := range sorted {
+		if idx == 0 || signY*p.Y < signY*out[len(out)-1].Y {
+			out = append(out, p)
+		}
+	}
+	return slices.Clip(out)
+}
+
+// BiggestSquare finds the largest rectangle with red tiles at opposite corners.
+//
+// Either the rectangle runs from the lowest x and y corner to the highest, or from lowest x and
+// highest y to highest x and lowest y. Moving a corner to a tile further towards its own corner
+// of the floor can only make the rectangle bigger, so only pairs across opposite frontiers need
+// checking. Those are a small part of the tiles, unless they are all on a staircase.
+func BiggestSquare(points []Point) Square {
+	if len(points) == 0 {
+		return Square{}
+	}
+	minX, maxX, minY, maxY := FindExtremes(points)
+	best := Square{Flag1: points[0], Flag2: points[0]}
+	check := func(corner1, corner2 []Point) {
+		slices.SortFunc(corner1, FurtherFromCenter(minX, maxX, minY, maxY))
+		for _, flag1 := range corner1 {
+			if biggestToCorner(minX, maxX, minY, maxY, flag1) <= best.Area() {
+				continue // no tile can be far enough away to beat it
+			}
+			for _, flag2 := range corner2 {
+				if square := (Square{Flag1: flag1, Flag2: flag2}); square.Area() > best.Area() {
+					best = square
+				}
+			}
+		}
+	}
+	check(Frontier(points, 1, 1), Frontier(points, -1, -1))
+	check(Frontier(points, 1, -1), Frontier(points, -1, 1))
+	return best
+}
@@ -0,0 +1,48 @@
+package day9_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jstensland/advent-of-code/2025/day9"
+)
+
+func TestFrontier(t *testing.T) {
+	points := parsePoints(t, example1())
+
+	assert.ElementsMatch(t, parsePoints(t, "2,3\n7,1"), day9.Frontier(points, 1, 1))
+	assert.ElementsMatch(t, parsePoints(t, "11,7"), day9.Frontier(points, -1, -1))
+	assert.ElementsMatch(t, parsePoints(t, "2,5\n9,7"), day9.Frontier(points, 1, -1))
+	assert.ElementsMatch(t, parsePoints(t, "11,1"), day9.Frontier(points, -1, 1))
+}
+
+func TestFrontier_Ties(t *testing.T) {
+	points := parsePoints(t, "0,0\n0,0\n0,5\n3,0\n3,5")
+
+	assert.Equal(t, parsePoints(t, "0,0"), day9.Frontier(points, 1, 1), "one of each repeated tile")
+	assert.Equal(t, parsePoints(t, "3,5"), day9.Frontier(points, -1, -1))
+}
+
+// TestBiggestSquare_MatchesBruteForce checks random tiles against trying every pair.
+func TestBiggestSquare_MatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewPCG(9, 2025)) //nolint:gosec // repeatable test tiles
+
+	for trial := range 500 {
+		size := 1 + rng.IntN(50)
+		points := make([]day9.Point, 1+rng.IntN(40))
+		for idx := range points {
+			points[idx] = day9.Point{X: rng.IntN(size), Y: rng.IntN(size)}
+		}
+
+		want := 0
+		for idx, flag1 := range points {
+			for _, flag2 := range points[idx:] {
+				want = max(want, day9.Square{Flag1: flag1, Flag2: flag2}.Area())
+			}
+		}
+
+		assert.Equal(t, want, day9.BiggestSquare(points).Area(), "trial %d: %v", trial, points)
+	}
+}
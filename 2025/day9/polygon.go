@@ -0,0 +1,162 @@
+package day9
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jstensland/advent-of-code/lib/grid"
+)
+
+var (
+	ErrTooFewTiles    = errors.New("need at least 2 red tiles")
+	ErrNotRectilinear = errors.New("red tiles must be joined by straight lines along a row or column")
+)
+
+type cell int
+
+const (
+	unknown cell = iota
+	edge         // on the loop of red and green tiles
+	outside
+)
+
+// Polygon is the loop of red tiles, joined by green tiles, and everything it encloses.
+//
+// The coordinates are huge, so the tiles are compressed. Each distinct x of a red tile gets a
+// column, and so does each gap between two of them, however wide. Same for y. The compressed
+// grid has an extra column and row around the outside, so the outside is connected.
+type Polygon struct {
+	red  []Point
+	xs   []int // distinct x of the red tiles, sorted
+	ys   []int // distinct y of the red tiles, sorted
+	grid *grid.Grid[cell]
+	// outside[r][c] counts the outside tiles in rows < r and columns < c of the compressed grid
+	outside [][]int
+}
+
+// NewPolygon compresses the red tiles, in order around the loop, and works out what is inside.
+func NewPolygon(red []Point) (*Polygon, error) {
+	if len(red) < 2 { //nolint:mnd // a line is the smallest loop
+		return nil, ErrTooFewTiles
+	}
+
+	p := &Polygon{red: red, xs: distinct(red, func(pt Point) int { return pt.X }), ys: distinct(red, func(pt Point) int { return pt.Y })}
+	p.grid = grid.New[cell](2*len(p.xs)+1, 2*len(p.ys)+1)
+
+	for idx, from := range red {
+		to := red[(idx+1)%len(red)]
+		if from.X != to.X && from.Y != to.Y {
+			return nil, fmt.Errorf("%w: %v to %v", ErrNotRectilinear, from, to)
+		}
+		p.drawEdge(p.compress(from), p.compress(to))
+	}
+	p.fillOutside()
+	p.sumOutside()
+	return p, nil
+}
+
+// distinct lists the distinct values of coord across the points, sorted.
+func distinct(points []Point, coord func(Point) int) []int {
+	vals := make([]int, 0, len(points))
+	for _, pt := range points {
+		vals = append(vals, coord(pt))
+	}
+	slices.Sort(vals)
+	return slices.Compact(vals)
+}
+
+// compress is where a red tile is in the compressed grid.
+func (p *Polygon) compress(pt Point) grid.Pos {
+	col, _ := slices.BinarySearch(p.xs, pt.X)
+	row, _ := slices.BinarySearch(p.ys, pt.Y)
+	return grid.Pos{Row: 2*row + 1, Col: 2*col + 1}
+}
+
+func (p *Polygon) drawEdge(from, to grid.Pos) {
+	for row := min(from.Row, to.Row); row <= max(from.Row, to.Row); row++ {
+		for col := min(from.Col, to.Col); col <= max(from.Col, to.Col); col++ {
+			p.grid.Set(grid.Pos{Row: row, Col: col}, edge)
+		}
+	}
+}
+
+// fillOutside marks every cell reachable from the corner without crossing the loop.
+func (p *Polygon) fillOutside() {
+	start := grid.Pos{Row: 0, Col: 0}
+	p.grid.Set(start, outside)
+	queue := []grid.Pos{start}
+	for len(queue) > 0 {
+		pos := queue[0]
+		queue = queue[1:]
+		for next := range p.grid.Neighbors4(pos) {
+			if p.grid.At(next) == unknown {
+				p.grid.Set(next, outside)
+				queue = append(queue, next)
+			}
+		}
+	}
+}
+
+// sumOutside builds the 2D prefix sum of outside tiles, so any rectangle can be checked in
+// constant time.
+//
+// It counts tiles rather than cells, because a gap between neighbouring columns of red tiles
+// has no tiles in it. The loop can pass either side of such a gap leaving it outside, but a
+// rectangle across it is still all red and green.
+func (p *Polygon) sumOutside() {
+	colTiles, rowTiles := tilesPerCell(p.xs), tilesPerCell(p.ys)
+	p.outside = make([][]int, p.grid.Height()+1)
+	p.outside[0] = make([]int, p.grid.Width()+1)
+	for row := range p.grid.Height() {
+		p.outside[row+1] = make([]int, p.grid.Width()+1)
+		for col := range p.grid.Width() {
+			count := 0
+			if p.grid.At(grid.Pos{Row: row, Col: col}) == outside {
+				count = rowTiles[row] * colTiles[col]
+			}
+			p.outside[row+1][col+1] = count + p.outside[row][col+1] + p.outside[row+1][col] - p.outside[row][col]
+		}
+	}
+}
+
+// tilesPerCell is how many tiles wide each compressed column is, or high for rows. Red tile
+// columns are one tile, gaps are however many tiles lie between, and the padding columns
+// count as one.
+func tilesPerCell(vals []int) []int {
+	out := make([]int, 2*len(vals)+1)
+	out[0], out[len(out)-1] = 1, 1
+	for idx, val := range vals {
+		out[2*idx+1] = 1
+		if idx > 0 {
+			out[2*idx] = val - vals[idx-1] - 1
+		}
+	}
+	return out
+}
+
+// Contains reports whether the rectangle with corners at the two red tiles is all red or
+// green, with no tile outside the loop.
+func (p *Polygon) Contains(s Square) bool {
+	c1, c2 := p.compress(s.Flag1), p.compress(s.Flag2)
+	top, bottom := min(c1.Row, c2.Row), max(c1.Row, c2.Row)
+	left, right := min(c1.Col, c2.Col), max(c1.Col, c2.Col)
+	count := p.outside[bottom+1][right+1] - p.outside[top][right+1] - p.outside[bottom+1][left] + p.outside[top][left]
+	return count == 0
+}
+
+// Biggest finds the largest rectangle with red tiles at opposite corners that is all inside
+// the loop.
+func (p *Polygon) Biggest() Square {
+	var best Square
+	bestArea := 0
+	for idx, flag1 := range p.red {
+		for _, flag2 := range p.red[idx+1:] {
+			square := Square{Flag1: flag1, Flag2: flag2}
+			if area := square.Area(); area > bestArea && p.Contains(square) {
+				best, bestArea = square, area
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,104 @@
+package day9_test
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/2025/day9"
+)
+
+func parsePoints(t *testing.T, in string) []day9.Point {
+	t.Helper()
+	points, err := day9.ParseIn(strings.NewReader(in))
+	require.NoError(t, err)
+	return points
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	polygon, err := day9.NewPolygon(parsePoints(t, example1()))
+	require.NoError(t, err)
+
+	assert.True(t, polygon.Contains(day9.Square{Flag1: day9.Point{X: 9, Y: 5}, Flag2: day9.Point{X: 2, Y: 3}}))
+	assert.True(t, polygon.Contains(day9.Square{Flag1: day9.Point{X: 7, Y: 3}, Flag2: day9.Point{X: 11, Y: 1}}))
+	assert.False(t, polygon.Contains(day9.Square{Flag1: day9.Point{X: 7, Y: 1}, Flag2: day9.Point{X: 11, Y: 7}}))
+	assert.False(t, polygon.Contains(day9.Square{Flag1: day9.Point{X: 2, Y: 5}, Flag2: day9.Point{X: 11, Y: 1}}))
+	assert.False(t, polygon.Contains(day9.Square{Flag1: day9.Point{X: 2, Y: 3}, Flag2: day9.Point{X: 7, Y: 1}}))
+}
+
+func TestPolygon_NoTilesBetweenArms(t *testing.T) {
+	// a U whose arms are in neighbouring columns, so the slot between them holds no tiles
+	polygon, err := day9.NewPolygon([]day9.Point{
+		{X: 0, Y: 0}, {X: 7, Y: 0}, {X: 7, Y: 5}, {X: 4, Y: 5}, {X: 4, Y: 1}, {X: 3, Y: 1}, {X: 3, Y: 5}, {X: 0, Y: 5},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 48, polygon.Biggest().Area())
+}
+
+func TestPolygon_Errors(t *testing.T) {
+	_, err := day9.NewPolygon([]day9.Point{{X: 1, Y: 1}})
+	require.ErrorIs(t, err, day9.ErrTooFewTiles)
+
+	_, err = day9.NewPolygon([]day9.Point{{X: 1, Y: 1}, {X: 1, Y: 4}, {X: 3, Y: 6}})
+	require.ErrorIs(t, err, day9.ErrNotRectilinear)
+}
+
+// TestPolygon_MatchesBruteForce checks random skyline shapes against testing every tile of
+// every rectangle.
+func TestPolygon_MatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewPCG(9, 2025)) //nolint:gosec // repeatable test shapes
+	for range 200 {
+		cols := 1 + rng.IntN(6)
+		xs := []int{rng.IntN(3)}
+		heights := make([]int, cols)
+		for idx := range cols {
+			xs = append(xs, xs[idx]+1+rng.IntN(4))
+			heights[idx] = 1 + rng.IntN(8)
+		}
+
+		// up the left, along each roof, and back along the floor
+		red := []day9.Point{{X: xs[0], Y: 0}}
+		for idx, height := range heights {
+			red = append(red, day9.Point{X: xs[idx], Y: height}, day9.Point{X: xs[idx+1], Y: height})
+		}
+		red = append(red, day9.Point{X: xs[cols], Y: 0})
+
+		// a tile is inside if it's under the roof of its column, or either column's at a wall
+		inside := func(x, y int) bool {
+			for idx, height := range heights {
+				if x >= xs[idx] && x <= xs[idx+1] && y >= 0 && y <= height {
+					return true
+				}
+			}
+			return false
+		}
+		want := 0
+		for i, p1 := range red {
+			for _, p2 := range red[i+1:] {
+				square := day9.Square{Flag1: p1, Flag2: p2}
+				if square.Area() > want && allInside(square, inside) {
+					want = square.Area()
+				}
+			}
+		}
+
+		polygon, err := day9.NewPolygon(red)
+		require.NoError(t, err)
+		require.Equal(t, want, polygon.Biggest().Area(), "%v", red)
+	}
+}
+
+func allInside(s day9.Square, inside func(x, y int) bool) bool {
+	for x := min(s.Flag1.X, s.Flag2.X); x <= max(s.Flag1.X, s.Flag2.X); x++ {
+		for y := min(s.Flag1.Y, s.Flag2.Y); y <= max(s.Flag1.Y, s.Flag2.Y); y++ {
+			if !inside(x, y) {
+				return false
+			}
+		}
+	}
+	return true
+}
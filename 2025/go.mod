@@ -2,11 +2,16 @@ module github.com/jstensland/advent-of-code/2025
 
 go 1.25
 
-require github.com/magefile/mage v1.15.0
+require (
+	github.com/jstensland/advent-of-code/lib v0.0.0-00010101000000-000000000000
+	github.com/magefile/mage v1.15.0
+	github.com/stretchr/testify v1.11.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.11.1
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/jstensland/advent-of-code/lib => ../lib
@@ -95,14 +95,21 @@ func GetInput(day string) error {
 // NewDay generates boilerplate code for a new day.
 // Usage: mage newDay 3.
 func NewDay(day string) error {
+	return NewDayKind(day, "blank")
+}
+
+// NewDayKind generates boilerplate code for a new day, starting from the parser for the given
+// input shape: blank, grid, lines, sections or ints.
+// Usage: mage newDayKind 3 grid.
+func NewDayKind(day, kind string) error {
 	dayNum, err := parseDay(day)
 	if err != nil {
 		return err
 	}
 
 	ctx := context.Background()
-	//nolint:gosec // dayNum is sanitized
-	cmd := exec.CommandContext(ctx, "go", "run", "./cmd/daygen", "-day", strconv.Itoa(dayNum))
+	//nolint:gosec // dayNum is sanitized and daygen validates kind
+	cmd := exec.CommandContext(ctx, "go", "run", "./cmd/daygen", "-day", strconv.Itoa(dayNum), "-kind", kind)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
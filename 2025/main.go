@@ -0,0 +1,32 @@
+// Package main runs the registered days against their inputs.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/jstensland/advent-of-code/2025/runner"
+)
+
+func main() {
+	day := flag.Int("day", 0, "only run the given day (default all)")
+	flag.Parse()
+
+	if *day == 0 {
+		if err := runner.Run(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	for _, registered := range runner.Days {
+		if registered.Num == *day {
+			if err := runner.RunDay(os.Stdout, registered); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+	log.Fatalf("day %d is not registered", *day)
+}
@@ -0,0 +1,30 @@
+// Code generated by daygen. DO NOT EDIT.
+
+package runner
+
+import (
+	"github.com/jstensland/advent-of-code/2025/day1"
+	"github.com/jstensland/advent-of-code/2025/day2"
+	"github.com/jstensland/advent-of-code/2025/day3"
+	"github.com/jstensland/advent-of-code/2025/day4"
+	"github.com/jstensland/advent-of-code/2025/day5"
+	"github.com/jstensland/advent-of-code/2025/day6"
+	"github.com/jstensland/advent-of-code/2025/day7"
+	"github.com/jstensland/advent-of-code/2025/day8"
+	"github.com/jstensland/advent-of-code/2025/day9"
+)
+
+// Days are all the days that have been generated, in order.
+//
+//nolint:gochecknoglobals // generated registry of days
+var Days = []Day{
+	{Num: 1, Part1: day1.Part1, Part2: day1.Part2},
+	{Num: 2, Part1: day2.Part1, Part2: day2.Part2},
+	{Num: 3, Part1: day3.Part1, Part2: day3.Part2},
+	{Num: 4, Part1: day4.Part1, Part2: day4.Part2},
+	{Num: 5, Part1: day5.Part1, Part2: day5.Part2},
+	{Num: 6, Part1: day6.Part1, Part2: day6.Part2},
+	{Num: 7, Part1: day7.Part1, Part2: day7.Part2},
+	{Num: 8, Part1: day8.Part1, Part2: day8.Part2},
+	{Num: 9, Part1: day9.Part1, Part2: day9.Part2},
+}
@@ -1,7 +1,59 @@
 // Package runner has generic runner logic to handle experimenting with each day
 package runner
 
-import "io"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
 
 // Solver is what each day part solver will implement. The reader is for the input.
 type Solver func(in io.Reader) (int, error)
+
+// Day is a registered day and its solvers. The list of days is generated by daygen in days.go.
+type Day struct {
+	Num   int
+	Part1 Solver
+	Part2 Solver
+}
+
+// Run solves both parts of every registered day against its input.txt and writes the answers to out.
+func Run(out io.Writer) error {
+	for _, day := range Days {
+		if err := RunDay(out, day); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunDay solves both parts of a single day against its input.txt and writes the answers to out.
+func RunDay(out io.Writer, day Day) error {
+	inFile := filepath.Join(fmt.Sprintf("day%d", day.Num), "input.txt")
+	for part, solver := range []Solver{day.Part1, day.Part2} {
+		answer, err := runIt(solver, inFile)
+		if err != nil {
+			return fmt.Errorf("day %d part %d: %w", day.Num, part+1, err)
+		}
+		if _, err := fmt.Fprintf(out, "Day %d Part %d: %v\n", day.Num, part+1, answer); err != nil {
+			return fmt.Errorf("failed to write answer: %w", err)
+		}
+	}
+	return nil
+}
+
+func runIt(solver Solver, inFile string) (answer int, err error) {
+	in, err := os.Open(filepath.Clean(inFile))
+	if err != nil {
+		return 0, fmt.Errorf("failed to open input: %w", err)
+	}
+	defer func() {
+		if closeErr := in.Close(); closeErr != nil {
+			err = errors.Join(err, closeErr)
+		}
+	}()
+
+	return solver(in)
+}
@@ -0,0 +1,132 @@
+// Package combo generates products, permutations, combinations and subsets of a slice lazily, as
+// iterators.
+//
+// Every generator takes a Prune callback. It sees each partial sequence as it is built, so a
+// search can drop a whole branch, like operator choices already past a target, without walking
+// every sequence under it.
+//
+// The yielded slice is reused between iterations. Clone it to keep it.
+package combo
+
+import "iter"
+
+// Prune reports whether no sequence starting with prefix is wanted. It is called with every
+// prefix, from length one up to and including the full sequence. A nil Prune keeps everything.
+type Prune[T any] func(prefix []T) bool
+
+func (p Prune[T]) cut(prefix []T) bool {
+	return p != nil && p(prefix)
+}
+
+// Product yields every sequence of n items, each picked from items with repeats allowed, so
+// there are len(items)^n of them. Earlier positions change slowest.
+func Product[T any](items []T, n int, prune Prune[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		buf := make([]T, n)
+		var build func(pos int) bool
+		build = func(pos int) bool {
+			if pos == n {
+				return yield(buf)
+			}
+			for _, item := range items {
+				buf[pos] = item
+				if prune.cut(buf[:pos+1]) {
+					continue
+				}
+				if !build(pos + 1) {
+					return false
+				}
+			}
+			return true
+		}
+		build(0)
+	}
+}
+
+// Permutations yields every ordering of k distinct items, in index order of items. Items are
+// distinguished by position, so duplicates in items give duplicate permutations.
+func Permutations[T any](items []T, k int, prune Prune[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if k > len(items) {
+			return
+		}
+		buf := make([]T, k)
+		used := make([]bool, len(items))
+		var build func(pos int) bool
+		build = func(pos int) bool {
+			if pos == k {
+				return yield(buf)
+			}
+			for idx, item := range items {
+				if used[idx] {
+					continue
+				}
+				buf[pos] = item
+				if prune.cut(buf[:pos+1]) {
+					continue
+				}
+				used[idx] = true
+				ok := build(pos + 1)
+				used[idx] = false
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}
+		build(0)
+	}
+}
+
+// Combinations yields every choice of k items, keeping the order they have in items.
+func Combinations[T any](items []T, k int, prune Prune[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if k > len(items) {
+			return
+		}
+		buf := make([]T, k)
+		var build func(pos, from int) bool
+		build = func(pos, from int) bool {
+			if pos == k {
+				return yield(buf)
+			}
+			// leave enough items to fill the rest of buf
+			for idx := from; idx <= len(items)-(k-pos); idx++ {
+				buf[pos] = items[idx]
+				if prune.cut(buf[:pos+1]) {
+					continue
+				}
+				if !build(pos+1, idx+1) {
+					return false
+				}
+			}
+			return true
+		}
+		build(0, 0)
+	}
+}
+
+// Subsets yields every subset of items, keeping the order they have in items. It goes depth
+// first from the empty subset, so {a}, {a b}, {a b c}, {a c}, {b} and so on. A subset is pruned
+// along with every larger subset that starts with it.
+func Subsets[T any](items []T, prune Prune[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		buf := make([]T, 0, len(items))
+		var build func(from int) bool
+		build = func(from int) bool {
+			if !yield(buf) {
+				return false
+			}
+			for idx := from; idx < len(items); idx++ {
+				buf = append(buf, items[idx])
+				ok := prune.cut(buf) || build(idx+1)
+				buf = buf[:len(buf)-1]
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}
+		build(0)
+	}
+}
@@ -0,0 +1,99 @@
+package combo_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jstensland/advent-of-code/lib/combo"
+)
+
+// collect joins each sequence into a string so results are easy to compare
+func collect(seq func(func([]string) bool)) []string {
+	out := []string{}
+	for s := range seq {
+		out = append(out, strings.Join(s, ""))
+	}
+	return out
+}
+
+func abc() []string {
+	return []string{"a", "b", "c"}
+}
+
+func TestProduct(t *testing.T) {
+	assert.Equal(t,
+		[]string{"aa", "ab", "ba", "bb"},
+		collect(combo.Product([]string{"a", "b"}, 2, nil)),
+	)
+	assert.Len(t, collect(combo.Product(abc(), 4, nil)), 81)
+	assert.Equal(t, []string{""}, collect(combo.Product(abc(), 0, nil)))
+}
+
+func TestPermutations(t *testing.T) {
+	assert.Equal(t,
+		[]string{"abc", "acb", "bac", "bca", "cab", "cba"},
+		collect(combo.Permutations(abc(), 3, nil)),
+	)
+	assert.Equal(t,
+		[]string{"ab", "ac", "ba", "bc", "ca", "cb"},
+		collect(combo.Permutations(abc(), 2, nil)),
+	)
+	assert.Empty(t, collect(combo.Permutations(abc(), 4, nil)))
+}
+
+func TestCombinations(t *testing.T) {
+	assert.Equal(t, []string{"ab", "ac", "bc"}, collect(combo.Combinations(abc(), 2, nil)))
+	assert.Equal(t, []string{"abc"}, collect(combo.Combinations(abc(), 3, nil)))
+	assert.Empty(t, collect(combo.Combinations(abc(), 4, nil)))
+}
+
+func TestSubsets(t *testing.T) {
+	assert.Equal(t,
+		[]string{"", "a", "ab", "abc", "ac", "b", "bc", "c"},
+		collect(combo.Subsets(abc(), nil)),
+	)
+}
+
+func TestPrune(t *testing.T) {
+	startsWithB := func(prefix []string) bool { return prefix[0] == "b" }
+	var seen [][]string
+	endsWithCOrStartsWithB := func(prefix []string) bool {
+		seen = append(seen, slices.Clone(prefix))
+		return startsWithB(prefix) || len(prefix) == 2 && prefix[1] == "c"
+	}
+
+	assert.Equal(t, []string{"aa", "ab", "ca", "cb"}, collect(combo.Product(abc(), 2, endsWithCOrStartsWithB)))
+	// "b" is pruned as a prefix of length one, so nothing under it is visited
+	assert.NotContains(t, seen, []string{"b", "a"})
+	assert.Contains(t, seen, []string{"a", "c"}, "full sequences are offered to prune too")
+
+	assert.Equal(t, []string{"ab", "ac", "ca", "cb"}, collect(combo.Permutations(abc(), 2, startsWithB)))
+	assert.Equal(t, []string{"ab", "ac"}, collect(combo.Combinations(abc(), 2, startsWithB)))
+	assert.Equal(t, []string{"", "a", "ab", "abc", "ac", "c"}, collect(combo.Subsets(abc(), startsWithB)))
+	assert.Equal(t, []string{"", "a", "ab", "ac", "b", "bc", "c"},
+		collect(combo.Subsets(abc(), func(p []string) bool { return len(p) > 2 })))
+}
+
+func TestStopEarly(t *testing.T) {
+	count := 0
+	for range combo.Product(abc(), 10, nil) {
+		count++
+		if count == 5 {
+			break
+		}
+	}
+	assert.Equal(t, 5, count)
+
+	for _, seq := range []func(func([]string) bool){
+		combo.Permutations(abc(), 3, nil),
+		combo.Combinations(abc(), 2, nil),
+		combo.Subsets(abc(), nil),
+	} {
+		for range seq {
+			break
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Package cycle finds where a sequence of states starts repeating, like a guard walking the same
+// route forever or a machine returning to an earlier configuration.
+//
+// The sequence is start, step(start), step(step(start)) and so on, until step reports it is
+// done. Step must be deterministic: the same state always leads to the same next state.
+package cycle
+
+// Step returns the state after s, or false if the sequence ends at s.
+type Step[S comparable] func(s S) (S, bool)
+
+// Result locates the cycle. The state at index Start is the first to repeat, and it comes
+// around again every Length steps.
+type Result struct {
+	Start  int
+	Length int
+}
+
+// Brent finds the cycle with Brent's algorithm. It keeps only two states in memory, at the cost
+// of calling step more often than HashSet, including replaying the sequence from start. It
+// reports false if the sequence ends instead.
+func Brent[S comparable](start S, step Step[S]) (Result, bool) {
+	// find the length by teleporting the tortoise to the hare at each power of two
+	power, length := 1, 1
+	tortoise := start
+	hare, ok := step(start)
+	if !ok {
+		return Result{}, false
+	}
+	for tortoise != hare {
+		if power == length {
+			tortoise = hare
+			power *= 2
+			length = 0
+		}
+		if hare, ok = step(hare); !ok {
+			return Result{}, false
+		}
+		length++
+	}
+
+	// with the hare a cycle ahead, they meet where the cycle starts
+	tortoise, hare = start, start
+	for range length {
+		hare, _ = step(hare)
+	}
+	first := 0
+	for tortoise != hare {
+		tortoise, _ = step(tortoise)
+		hare, _ = step(hare)
+		first++
+	}
+	return Result{Start: first, Length: length}, true
+}
+
+// HashSet finds the cycle by remembering the index of every state seen. It calls step once per
+// state, but holds every state until the first repeat. It reports false if the sequence ends
+// instead.
+func HashSet[S comparable](start S, step Step[S]) (Result, bool) {
+	seen := map[S]int{}
+	state := start
+	for idx := 0; ; idx++ {
+		if first, ok := seen[state]; ok {
+			return Result{Start: first, Length: idx - first}, true
+		}
+		seen[state] = idx
+
+		var ok bool
+		if state, ok = step(state); !ok {
+			return Result{}, false
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cycle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jstensland/advent-of-code/lib/cycle"
+)
+
+type finder func(int, cycle.Step[int]) (cycle.Result, bool)
+
+func finders() map[string]finder {
+	return map[string]finder{
+		"brent":   cycle.Brent[int],
+		"hashset": cycle.HashSet[int],
+	}
+}
+
+// rho counts up from 0 to tail+length-1, then loops back to tail
+func rho(tail, length int) cycle.Step[int] {
+	return func(s int) (int, bool) {
+		if s == tail+length-1 {
+			return tail, true
+		}
+		return s + 1, true
+	}
+}
+
+func TestFind(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		start int
+		step  cycle.Step[int]
+		want  cycle.Result
+	}{
+		{desc: "fixed point", step: func(int) (int, bool) { return 0, true }, want: cycle.Result{Start: 0, Length: 1}},
+		{desc: "pure loop", step: rho(0, 5), want: cycle.Result{Start: 0, Length: 5}},
+		{desc: "tail then loop", step: rho(3, 4), want: cycle.Result{Start: 3, Length: 4}},
+		{desc: "long tail", step: rho(1000, 1), want: cycle.Result{Start: 1000, Length: 1}},
+		{desc: "long loop", step: rho(7, 1025), want: cycle.Result{Start: 7, Length: 1025}},
+		{
+			desc:  "mod power",
+			start: 1,
+			step:  func(s int) (int, bool) { return s * 3 % 100, true },
+			want:  cycle.Result{Start: 0, Length: 20}, // 3 has order 20 mod 100
+		},
+	}
+	for name, find := range finders() {
+		for _, tC := range testCases {
+			t.Run(name+" "+tC.desc, func(t *testing.T) {
+				got, ok := find(tC.start, tC.step)
+
+				assert.True(t, ok)
+				assert.Equal(t, tC.want, got)
+			})
+		}
+	}
+}
+
+func TestFind_Ends(t *testing.T) {
+	countdown := func(s int) (int, bool) { return s - 1, s > 0 }
+	for name, find := range finders() {
+		t.Run(name, func(t *testing.T) {
+			_, ok := find(10, countdown)
+			assert.False(t, ok)
+
+			_, ok = find(0, countdown)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func BenchmarkFind(b *testing.B) {
+	for name, find := range finders() {
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				find(0, rho(5000, 3000))
+			}
+		})
+	}
+}
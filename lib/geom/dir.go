@@ -0,0 +1,138 @@
+package geom
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnknownDirection = errors.New("unknown direction")
+
+// Dir4 is one of the four directions along rows and columns, in clockwise order.
+type Dir4 int
+
+const (
+	North Dir4 = iota
+	East
+	South
+	West
+)
+
+// The same directions, named the way most maps describe them.
+const (
+	Up    = North
+	Right = East
+	Down  = South
+	Left  = West
+)
+
+const (
+	numDir4 = 4
+	numDir8 = 8
+)
+
+// AllDir4 returns every Dir4, clockwise from North.
+func AllDir4() [numDir4]Dir4 {
+	return [numDir4]Dir4{North, East, South, West}
+}
+
+// ParseDir4 reads a direction from an arrow (^>v<) or a compass letter (NESW).
+func ParseDir4(r rune) (Dir4, error) {
+	switch r {
+	case '^', 'N':
+		return North, nil
+	case '>', 'E':
+		return East, nil
+	case 'v', 'S':
+		return South, nil
+	case '<', 'W':
+		return West, nil
+	}
+	return North, fmt.Errorf("%q: %w", r, ErrUnknownDirection)
+}
+
+// Rotate turns the direction clockwise by a quarter turn per step. Negative steps turn
+// counterclockwise.
+func (d Dir4) Rotate(steps int) Dir4 {
+	return Dir4(mod(int(d)+steps, numDir4))
+}
+
+// Clockwise returns the direction to the right.
+func (d Dir4) Clockwise() Dir4 { return d.Rotate(1) }
+
+// CounterClockwise returns the direction to the left.
+func (d Dir4) CounterClockwise() Dir4 { return d.Rotate(-1) }
+
+// Reverse returns the opposite direction.
+func (d Dir4) Reverse() Dir4 { return d.Rotate(numDir4 / 2) } //nolint:mnd // half a turn
+
+// Vec is a single step in the direction.
+func (d Dir4) Vec() Vec {
+	return [numDir4]Vec{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}[d]
+}
+
+// Dir8 converts to the matching compass direction.
+func (d Dir4) Dir8() Dir8 {
+	return Dir8(d * 2) //nolint:mnd // every other Dir8 is a Dir4
+}
+
+// String draws the direction as an arrow, the way puzzles usually do.
+func (d Dir4) String() string {
+	return [numDir4]string{"^", ">", "v", "<"}[d]
+}
+
+// Dir8 is one of the eight compass directions, including diagonals, in clockwise order.
+type Dir8 int
+
+const (
+	N Dir8 = iota
+	NE
+	E
+	SE
+	S
+	SW
+	W
+	NW
+)
+
+// AllDir8 returns every Dir8, clockwise from N.
+func AllDir8() [numDir8]Dir8 {
+	return [numDir8]Dir8{N, NE, E, SE, S, SW, W, NW}
+}
+
+// ParseDir8 reads a compass direction like N or SW. Arrows (^>v<) are read as the four
+// straight directions.
+func ParseDir8(s string) (Dir8, error) {
+	for _, d := range AllDir8() {
+		if s == d.String() {
+			return d, nil
+		}
+	}
+	if r := []rune(s); len(r) == 1 {
+		if d, err := ParseDir4(r[0]); err == nil {
+			return d.Dir8(), nil
+		}
+	}
+	return N, fmt.Errorf("%q: %w", s, ErrUnknownDirection)
+}
+
+// Rotate turns the direction clockwise by an eighth of a turn per step. Negative steps turn
+// counterclockwise.
+func (d Dir8) Rotate(steps int) Dir8 {
+	return Dir8(mod(int(d)+steps, numDir8))
+}
+
+// Reverse returns the opposite direction.
+func (d Dir8) Reverse() Dir8 { return d.Rotate(numDir8 / 2) } //nolint:mnd // half a turn
+
+// Vec is a single step in the direction.
+func (d Dir8) Vec() Vec {
+	return [numDir8]Vec{{-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}}[d]
+}
+
+func (d Dir8) String() string {
+	return [numDir8]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}[d]
+}
+
+func mod(a, b int) int {
+	return ((a % b) + b) % b
+}
@@ -0,0 +1,72 @@
+// Package geom has the points, vectors and directions that grid puzzles keep needing.
+package geom
+
+// Point is a location on a 2D plane, using the row and column convention of puzzle maps.
+type Point struct {
+	// Row starts at zero and goes top to bottom
+	Row int
+	// Col starts at zero and goes left to right
+	Col int
+}
+
+// Vec is the difference between two points.
+type Vec struct {
+	Row int
+	Col int
+}
+
+// Add moves the point by the vector.
+func (p Point) Add(v Vec) Point {
+	return Point{Row: p.Row + v.Row, Col: p.Col + v.Col}
+}
+
+// Sub returns the vector that goes from q to p.
+func (p Point) Sub(q Point) Vec {
+	return Vec{Row: p.Row - q.Row, Col: p.Col - q.Col}
+}
+
+// Move returns the point one step away in the given direction.
+func (p Point) Move(d Dir4) Point {
+	return p.Add(d.Vec())
+}
+
+// Compare orders points row by row, then column by column, for use with slices.SortFunc.
+func Compare(a, b Point) int {
+	if a.Row != b.Row {
+		return a.Row - b.Row
+	}
+	return a.Col - b.Col
+}
+
+// Add returns the sum of the two vectors.
+func (v Vec) Add(w Vec) Vec {
+	return Vec{Row: v.Row + w.Row, Col: v.Col + w.Col}
+}
+
+// Scale multiplies the vector by n.
+func (v Vec) Scale(n int) Vec {
+	return Vec{Row: v.Row * n, Col: v.Col * n}
+}
+
+// Reverse returns the vector pointing the other way.
+func (v Vec) Reverse() Vec {
+	return Vec{Row: -v.Row, Col: -v.Col}
+}
+
+// Manhattan is the distance between points moving only along rows and columns.
+func Manhattan(a, b Point) int {
+	return abs(a.Row-b.Row) + abs(a.Col-b.Col)
+}
+
+// Chebyshev is the distance between points when diagonal moves cost the same as straight
+// ones, like a king on a chess board.
+func Chebyshev(a, b Point) int {
+	return max(abs(a.Row-b.Row), abs(a.Col-b.Col))
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
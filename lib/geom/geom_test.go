@@ -0,0 +1,120 @@
+package geom_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/lib/geom"
+)
+
+func pt(row, col int) geom.Point {
+	return geom.Point{Row: row, Col: col}
+}
+
+func TestPointVec(t *testing.T) {
+	a, b := pt(1, 2), pt(4, -2)
+
+	assert.Equal(t, geom.Vec{Row: 3, Col: -4}, b.Sub(a))
+	assert.Equal(t, b, a.Add(b.Sub(a)))
+	assert.Equal(t, pt(0, 2), a.Move(geom.Up))
+	assert.Equal(t, pt(1, 1), a.Move(geom.West))
+	assert.Equal(t, geom.Vec{Row: -6, Col: 8}, b.Sub(a).Scale(2).Reverse())
+	assert.Equal(t, geom.Vec{Row: 1, Col: 1}, geom.South.Vec().Add(geom.East.Vec()))
+}
+
+func TestCompare(t *testing.T) {
+	points := []geom.Point{pt(2, 0), pt(0, 5), pt(0, 1), pt(1, 9)}
+
+	slices.SortFunc(points, geom.Compare)
+
+	assert.Equal(t, []geom.Point{pt(0, 1), pt(0, 5), pt(1, 9), pt(2, 0)}, points)
+}
+
+func TestDistance(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		a, b      geom.Point
+		manhattan int
+		chebyshev int
+	}{
+		{desc: "same point", a: pt(3, 3), b: pt(3, 3), manhattan: 0, chebyshev: 0},
+		{desc: "straight", a: pt(0, 0), b: pt(0, -4), manhattan: 4, chebyshev: 4},
+		{desc: "diagonal", a: pt(0, 0), b: pt(3, 3), manhattan: 6, chebyshev: 3},
+		{desc: "mixed", a: pt(-1, 2), b: pt(4, 0), manhattan: 7, chebyshev: 5},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.manhattan, geom.Manhattan(tC.a, tC.b))
+			assert.Equal(t, tC.manhattan, geom.Manhattan(tC.b, tC.a))
+			assert.Equal(t, tC.chebyshev, geom.Chebyshev(tC.a, tC.b))
+			assert.Equal(t, tC.chebyshev, geom.Chebyshev(tC.b, tC.a))
+		})
+	}
+}
+
+func TestDir4Rotate(t *testing.T) {
+	assert.Equal(t, geom.Right, geom.Up.Clockwise())
+	assert.Equal(t, geom.Down, geom.Right.Clockwise())
+	assert.Equal(t, geom.Left, geom.Down.Clockwise())
+	assert.Equal(t, geom.Up, geom.Left.Clockwise())
+
+	assert.Equal(t, geom.West, geom.North.CounterClockwise())
+	assert.Equal(t, geom.North, geom.East.CounterClockwise())
+
+	assert.Equal(t, geom.South, geom.North.Reverse())
+	assert.Equal(t, geom.East, geom.West.Reverse())
+
+	assert.Equal(t, geom.West, geom.North.Rotate(-5))
+	assert.Equal(t, geom.North, geom.North.Rotate(8))
+}
+
+func TestParseDir4(t *testing.T) {
+	for idx, arrow := range "^>v<" {
+		dir, err := geom.ParseDir4(arrow)
+		require.NoError(t, err)
+		assert.Equal(t, geom.AllDir4()[idx], dir)
+		assert.Equal(t, string(arrow), dir.String())
+	}
+	for idx, letter := range "NESW" {
+		dir, err := geom.ParseDir4(letter)
+		require.NoError(t, err)
+		assert.Equal(t, geom.AllDir4()[idx], dir)
+	}
+
+	_, err := geom.ParseDir4('x')
+	require.ErrorIs(t, err, geom.ErrUnknownDirection)
+}
+
+func TestDir8(t *testing.T) {
+	assert.Equal(t, geom.NE, geom.N.Rotate(1))
+	assert.Equal(t, geom.NW, geom.N.Rotate(-1))
+	assert.Equal(t, geom.SW, geom.NE.Reverse())
+	assert.Equal(t, geom.W, geom.West.Dir8())
+
+	for _, dir := range geom.AllDir4() {
+		assert.Equal(t, dir.Vec(), dir.Dir8().Vec(), "%v should match", dir)
+	}
+
+	// every direction is undone by its reverse
+	for _, dir := range geom.AllDir8() {
+		assert.Equal(t, geom.Vec{}, dir.Vec().Add(dir.Reverse().Vec()), "%v should cancel out", dir)
+	}
+}
+
+func TestParseDir8(t *testing.T) {
+	for _, dir := range geom.AllDir8() {
+		parsed, err := geom.ParseDir8(dir.String())
+		require.NoError(t, err)
+		assert.Equal(t, dir, parsed)
+	}
+
+	dir, err := geom.ParseDir8("v")
+	require.NoError(t, err)
+	assert.Equal(t, geom.S, dir)
+
+	_, err = geom.ParseDir8("NNE")
+	require.ErrorIs(t, err, geom.ErrUnknownDirection)
+}
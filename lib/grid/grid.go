@@ -0,0 +1,276 @@
+// Package grid is a generic 2D grid of cells, as found in most map based puzzles.
+package grid
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strings"
+
+	"github.com/jstensland/advent-of-code/lib/geom"
+)
+
+var (
+	ErrRagged      = errors.New("rows have different widths")
+	ErrUnknownCell = errors.New("unknown cell")
+)
+
+// Pos is a location on the grid.
+type Pos = geom.Point
+
+// Grid is a rectangle of cells stored in row major order.
+type Grid[T any] struct {
+	cells  []T
+	width  int
+	height int
+}
+
+// New returns a grid of the given size with every cell set to the zero value.
+func New[T any](width, height int) *Grid[T] {
+	return &Grid[T]{
+		cells:  make([]T, width*height),
+		width:  width,
+		height: height,
+	}
+}
+
+// FromRows builds a grid from rows of cells. Every row must be the same width.
+func FromRows[T any](rows [][]T) (*Grid[T], error) {
+	g := &Grid[T]{height: len(rows)}
+	if len(rows) > 0 {
+		g.width = len(rows[0])
+	}
+	g.cells = make([]T, 0, g.width*g.height)
+	for idx, row := range rows {
+		if len(row) != g.width {
+			return nil, fmt.Errorf("row %d has width %d, expected %d: %w", idx+1, len(row), g.width, ErrRagged)
+		}
+		g.cells = append(g.cells, row...)
+	}
+	return g, nil
+}
+
+// FromLines builds a grid from lines of text, converting each rune with toCell.
+func FromLines[T any](lines []string, toCell func(rune) (T, error)) (*Grid[T], error) {
+	rows := make([][]T, 0, len(lines))
+	for idx, line := range lines {
+		row := make([]T, 0, len(line))
+		for col, char := range []rune(line) {
+			cell, err := toCell(char)
+			if err != nil {
+				return nil, fmt.Errorf("line %d column %d: %w", idx+1, col+1, err)
+			}
+			row = append(row, cell)
+		}
+		rows = append(rows, row)
+	}
+	return FromRows(rows)
+}
+
+// Parse reads a grid from the input, one row per line, converting each rune with toCell.
+// Leading blank lines are skipped, and the grid ends at the next blank line or the end of
+// the input, so anything after the grid can be read from the same reader.
+func Parse[T any](r io.Reader, toCell func(rune) (T, error)) (*Grid[T], error) {
+	scanner := bufio.NewScanner(r)
+	lines := []string{}
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			if len(lines) == 0 {
+				continue
+			}
+			break
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failure scanning input: %w", err)
+	}
+	return FromLines(lines, toCell)
+}
+
+// Runes is a toCell function that keeps the input characters as they are.
+func Runes(r rune) (rune, error) { return r, nil }
+
+// Mapping returns a toCell function that looks up each character in cells. Any character
+// not in the mapping is an error.
+func Mapping[T any](cells map[rune]T) func(rune) (T, error) {
+	return func(r rune) (T, error) {
+		cell, ok := cells[r]
+		if !ok {
+			var zero T
+			return zero, fmt.Errorf("%q: %w", r, ErrUnknownCell)
+		}
+		return cell, nil
+	}
+}
+
+func (g *Grid[T]) Width() int  { return g.width }
+func (g *Grid[T]) Height() int { return g.height }
+
+// InBounds returns true if the position is on the grid.
+func (g *Grid[T]) InBounds(p Pos) bool {
+	return p.Row >= 0 && p.Row < g.height && p.Col >= 0 && p.Col < g.width
+}
+
+// Get returns the cell at the position, and false if the position is off the grid.
+func (g *Grid[T]) Get(p Pos) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Row*g.width+p.Col], true
+}
+
+// GetOr returns the cell at the position, or fallback if the position is off the grid.
+func (g *Grid[T]) GetOr(p Pos, fallback T) T {
+	if cell, ok := g.Get(p); ok {
+		return cell
+	}
+	return fallback
+}
+
+// At returns the cell at a position known to be on the grid. It panics otherwise.
+func (g *Grid[T]) At(p Pos) T {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("position %v is off the %dx%d grid", p, g.width, g.height))
+	}
+	return g.cells[p.Row*g.width+p.Col]
+}
+
+// Set updates the cell at the position. It returns false, changing nothing, if the position
+// is off the grid.
+func (g *Grid[T]) Set(p Pos, cell T) bool {
+	if !g.InBounds(p) {
+		return false
+	}
+	g.cells[p.Row*g.width+p.Col] = cell
+	return true
+}
+
+// Positions iterates over every position, row by row.
+func (g *Grid[T]) Positions() iter.Seq[Pos] {
+	return func(yield func(Pos) bool) {
+		for row := range g.height {
+			for col := range g.width {
+				if !yield(Pos{Row: row, Col: col}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// All iterates over every position and its cell, row by row.
+func (g *Grid[T]) All() iter.Seq2[Pos, T] {
+	return func(yield func(Pos, T) bool) {
+		for idx, cell := range g.cells {
+			if !yield(Pos{Row: idx / g.width, Col: idx % g.width}, cell) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors4 iterates over the up, right, down and left neighbors that are on the grid.
+func (g *Grid[T]) Neighbors4(p Pos) iter.Seq[Pos] {
+	return func(yield func(Pos) bool) {
+		for _, dir := range geom.AllDir4() {
+			if next := p.Add(dir.Vec()); g.InBounds(next) && !yield(next) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors8 iterates over the neighbors that are on the grid, including diagonals, clockwise
+// from up.
+func (g *Grid[T]) Neighbors8(p Pos) iter.Seq[Pos] {
+	return func(yield func(Pos) bool) {
+		for _, dir := range geom.AllDir8() {
+			if next := p.Add(dir.Vec()); g.InBounds(next) && !yield(next) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the grid that can be changed independently.
+func (g *Grid[T]) Clone() *Grid[T] {
+	out := &Grid[T]{
+		cells:  make([]T, len(g.cells)),
+		width:  g.width,
+		height: g.height,
+	}
+	copy(out.cells, g.cells)
+	return out
+}
+
+// CopyFrom overwrites the cells with those of another grid of the same size, reusing
+// the memory already allocated.
+func (g *Grid[T]) CopyFrom(other *Grid[T]) {
+	if g.width != other.width || g.height != other.height {
+		panic("grids must be the same size to copy")
+	}
+	copy(g.cells, other.cells)
+}
+
+// FindAll returns the positions of every cell equal to target, row by row.
+func FindAll[T comparable](g *Grid[T], target T) []Pos {
+	out := []Pos{}
+	for pos, cell := range g.All() {
+		if cell == target {
+			out = append(out, pos)
+		}
+	}
+	return out
+}
+
+// Find returns the first position, row by row, of a cell equal to target.
+func Find[T comparable](g *Grid[T], target T) (Pos, bool) {
+	for pos, cell := range g.All() {
+		if cell == target {
+			return pos, true
+		}
+	}
+	return Pos{}, false
+}
+
+// Render draws the grid with one character per cell, and a newline after each row.
+func (g *Grid[T]) Render(toRune func(T) rune) string {
+	var sb strings.Builder
+	sb.Grow((g.width + 1) * g.height)
+	for idx, cell := range g.cells {
+		sb.WriteRune(toRune(cell))
+		if idx%g.width == g.width-1 {
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+// String renders the grid. Cells that are fmt.Stringers are drawn with String, rune based
+// cells as themselves, and anything else with fmt.
+func (g *Grid[T]) String() string {
+	var sb strings.Builder
+	for idx, cell := range g.cells {
+		sb.WriteString(cellString(cell))
+		if idx%g.width == g.width-1 {
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+func cellString(cell any) string {
+	if stringer, ok := cell.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	if val := reflect.ValueOf(cell); val.Kind() == reflect.Int32 {
+		return string(rune(val.Int()))
+	}
+	return fmt.Sprint(cell)
+}
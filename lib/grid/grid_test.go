@@ -0,0 +1,163 @@
+package grid_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/lib/grid"
+)
+
+type cell int
+
+const (
+	empty cell = iota
+	wall
+)
+
+func (c cell) String() string {
+	if c == wall {
+		return "#"
+	}
+	return "."
+}
+
+func pos(row, col int) grid.Pos {
+	return grid.Pos{Row: row, Col: col}
+}
+
+func example() string {
+	return `..#.
+#...
+..#.`
+}
+
+func TestParse(t *testing.T) {
+	g, err := grid.Parse(strings.NewReader(example()), grid.Mapping(map[rune]cell{'.': empty, '#': wall}))
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, g.Width())
+	assert.Equal(t, 3, g.Height())
+	assert.Equal(t, wall, g.At(pos(0, 2)))
+	assert.Equal(t, wall, g.At(pos(1, 0)))
+	assert.Equal(t, empty, g.At(pos(2, 3)))
+	assert.Equal(t, example()+"\n", g.String())
+}
+
+func TestParse_StopsAtBlankLine(t *testing.T) {
+	in := strings.NewReader("\n" + example() + "\n\n<>^v\n")
+
+	g, err := grid.Parse(in, grid.Runes)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, g.Height())
+	assert.Equal(t, example()+"\n", g.String())
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := grid.Parse(strings.NewReader("...\n.."), grid.Runes)
+	require.ErrorIs(t, err, grid.ErrRagged)
+
+	_, err = grid.Parse(strings.NewReader("..\n.x"), grid.Mapping(map[rune]cell{'.': empty}))
+	require.ErrorIs(t, err, grid.ErrUnknownCell)
+	assert.Contains(t, err.Error(), "line 2 column 2")
+}
+
+func TestParse_Empty(t *testing.T) {
+	g, err := grid.Parse(strings.NewReader(""), grid.Runes)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, g.Width())
+	assert.Equal(t, 0, g.Height())
+	assert.Empty(t, slices.Collect(g.Positions()))
+	assert.Empty(t, g.String())
+}
+
+func TestBounds(t *testing.T) {
+	g := grid.New[int](3, 2)
+
+	for _, off := range []grid.Pos{pos(-1, 0), pos(0, -1), pos(2, 0), pos(0, 3)} {
+		assert.False(t, g.InBounds(off), "%v should be off the grid", off)
+		_, ok := g.Get(off)
+		assert.False(t, ok)
+		assert.False(t, g.Set(off, 1))
+		assert.Equal(t, 7, g.GetOr(off, 7))
+		assert.Panics(t, func() { g.At(off) })
+	}
+
+	assert.True(t, g.Set(pos(1, 2), 5))
+	val, ok := g.Get(pos(1, 2))
+	assert.True(t, ok)
+	assert.Equal(t, 5, val)
+}
+
+func TestPositionsAndAll(t *testing.T) {
+	g, err := grid.FromRows([][]rune{[]rune("ab"), []rune("cd")})
+	require.NoError(t, err)
+
+	assert.Equal(t, []grid.Pos{pos(0, 0), pos(0, 1), pos(1, 0), pos(1, 1)}, slices.Collect(g.Positions()))
+
+	got := ""
+	for at, r := range g.All() {
+		assert.Equal(t, r, g.At(at))
+		got += string(r)
+	}
+	assert.Equal(t, "abcd", got)
+}
+
+func TestNeighbors(t *testing.T) {
+	g := grid.New[int](3, 3)
+
+	assert.Equal(t,
+		[]grid.Pos{pos(0, 1), pos(1, 2), pos(2, 1), pos(1, 0)},
+		slices.Collect(g.Neighbors4(pos(1, 1))),
+	)
+	assert.Len(t, slices.Collect(g.Neighbors8(pos(1, 1))), 8)
+
+	// corners only have the neighbors on the grid
+	assert.Equal(t, []grid.Pos{pos(0, 1), pos(1, 0)}, slices.Collect(g.Neighbors4(pos(0, 0))))
+	assert.Equal(t, []grid.Pos{pos(1, 2), pos(2, 1), pos(1, 1)}, slices.Collect(g.Neighbors8(pos(2, 2))))
+}
+
+func TestFind(t *testing.T) {
+	g, err := grid.Parse(strings.NewReader(example()), grid.Runes)
+	require.NoError(t, err)
+
+	assert.Equal(t, []grid.Pos{pos(0, 2), pos(1, 0), pos(2, 2)}, grid.FindAll(g, '#'))
+	assert.Empty(t, grid.FindAll(g, 'x'))
+
+	found, ok := grid.Find(g, '#')
+	assert.True(t, ok)
+	assert.Equal(t, pos(0, 2), found)
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	g, err := grid.Parse(strings.NewReader(example()), grid.Runes)
+	require.NoError(t, err)
+
+	clone := g.Clone()
+	clone.Set(pos(0, 0), 'X')
+
+	assert.Equal(t, '.', g.At(pos(0, 0)))
+	assert.Equal(t, 'X', clone.At(pos(0, 0)))
+
+	clone.CopyFrom(g)
+	assert.Equal(t, '.', clone.At(pos(0, 0)))
+}
+
+func TestRender(t *testing.T) {
+	g, err := grid.Parse(strings.NewReader(example()), grid.Runes)
+	require.NoError(t, err)
+
+	out := g.Render(func(r rune) rune {
+		if r == '#' {
+			return 'X'
+		}
+		return ' '
+	})
+
+	assert.Equal(t, "  X \nX   \n  X \n", out)
+}
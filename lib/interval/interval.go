@@ -0,0 +1,205 @@
+// Package interval is a set of integers stored as sorted, inclusive spans, for puzzles
+// about ranges of IDs, seeds or coordinates too large to hold one at a time.
+package interval
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"slices"
+)
+
+// Span is every integer from Start to End, inclusive. A span that ends before it starts is
+// empty.
+type Span struct {
+	Start int
+	End   int
+}
+
+// Len is the number of integers in the span.
+func (s Span) Len() int {
+	if s.Empty() {
+		return 0
+	}
+	return s.End - s.Start + 1
+}
+
+// Empty returns true if the span has no integers.
+func (s Span) Empty() bool { return s.End < s.Start }
+
+// Contains returns true if n is in the span.
+func (s Span) Contains(n int) bool { return s.Start <= n && n <= s.End }
+
+// Overlaps returns true if the spans share at least one integer.
+func (s Span) Overlaps(o Span) bool {
+	return !s.Empty() && !o.Empty() && s.Start <= o.End && o.Start <= s.End
+}
+
+// Set is a set of integers kept as sorted spans that don't overlap or touch. Spans that
+// overlap, or meet end to end like 1-3 and 4-6, are merged as they're added.
+//
+// The zero value is an empty set ready to use.
+type Set struct {
+	spans []Span
+}
+
+// New returns a set of all the integers in the spans.
+func New(spans ...Span) *Set {
+	s := &Set{}
+	for _, span := range spans {
+		s.Insert(span)
+	}
+	return s
+}
+
+// first returns the index of the first span that ends at or after n.
+func (s *Set) first(n int) int {
+	idx, _ := slices.BinarySearchFunc(s.spans, n, func(span Span, n int) int {
+		return cmp.Compare(span.End, n)
+	})
+	return idx
+}
+
+// below is n-1, or n if nothing is below it.
+func below(n int) int {
+	if n == math.MinInt {
+		return n
+	}
+	return n - 1
+}
+
+// above is n+1, or n if nothing is above it.
+func above(n int) int {
+	if n == math.MaxInt {
+		return n
+	}
+	return n + 1
+}
+
+// Insert adds every integer in the span.
+func (s *Set) Insert(span Span) {
+	if span.Empty() {
+		return
+	}
+	lo := s.first(below(span.Start)) // a span ending just before joins on
+	hi := lo
+	for hi < len(s.spans) && s.spans[hi].Start <= above(span.End) {
+		span.Start = min(span.Start, s.spans[hi].Start)
+		span.End = max(span.End, s.spans[hi].End)
+		hi++
+	}
+	s.spans = slices.Replace(s.spans, lo, hi, span)
+}
+
+// Delete removes every integer in the span.
+func (s *Set) Delete(span Span) {
+	if span.Empty() {
+		return
+	}
+	lo := s.first(span.Start)
+	hi := lo
+	remaining := []Span{}
+	for hi < len(s.spans) && s.spans[hi].Start <= span.End {
+		existing := s.spans[hi]
+		if existing.Start < span.Start {
+			remaining = append(remaining, Span{existing.Start, span.Start - 1})
+		}
+		if span.End < existing.End {
+			remaining = append(remaining, Span{span.End + 1, existing.End})
+		}
+		hi++
+	}
+	s.spans = slices.Replace(s.spans, lo, hi, remaining...)
+}
+
+// Contains returns true if n is in the set.
+func (s *Set) Contains(n int) bool {
+	idx := s.first(n)
+	return idx < len(s.spans) && s.spans[idx].Contains(n)
+}
+
+// ContainsSpan returns true if every integer in the span is in the set.
+func (s *Set) ContainsSpan(span Span) bool {
+	if span.Empty() {
+		return true
+	}
+	idx := s.first(span.Start)
+	// spans in the set never touch, so one of them has to cover it all
+	return idx < len(s.spans) && s.spans[idx].Contains(span.Start) && s.spans[idx].Contains(span.End)
+}
+
+// Overlaps returns true if any integer in the span is in the set.
+func (s *Set) Overlaps(span Span) bool {
+	idx := s.first(span.Start)
+	return idx < len(s.spans) && s.spans[idx].Overlaps(span)
+}
+
+// Len is the number of integers in the set.
+func (s *Set) Len() int {
+	total := 0
+	for _, span := range s.spans {
+		total += span.Len()
+	}
+	return total
+}
+
+// Spans returns a copy of the spans in the set, in order.
+func (s *Set) Spans() []Span { return slices.Clone(s.spans) }
+
+// All iterates over the spans in the set, in order.
+func (s *Set) All() iter.Seq[Span] { return slices.Values(s.spans) }
+
+// Clone returns a copy of the set that can be changed independently.
+func (s *Set) Clone() *Set { return &Set{spans: slices.Clone(s.spans)} }
+
+// Union returns a new set with the integers in either set.
+func (s *Set) Union(o *Set) *Set {
+	out := s.Clone()
+	for _, span := range o.spans {
+		out.Insert(span)
+	}
+	return out
+}
+
+// Intersect returns a new set with the integers in both sets.
+func (s *Set) Intersect(o *Set) *Set {
+	out := &Set{}
+	i, j := 0, 0
+	for i < len(s.spans) && j < len(o.spans) {
+		a, b := s.spans[i], o.spans[j]
+		if overlap := (Span{max(a.Start, b.Start), min(a.End, b.End)}); !overlap.Empty() {
+			out.spans = append(out.spans, overlap) // both inputs are sorted, so this is too
+		}
+		if a.End < b.End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return out
+}
+
+// Complement returns a new set with the integers within bounds that are not in this set.
+func (s *Set) Complement(bounds Span) *Set {
+	out := &Set{}
+	next := bounds.Start // smallest integer not yet accounted for
+	for _, span := range s.spans {
+		if span.End < bounds.Start {
+			continue
+		}
+		if span.Start > bounds.End {
+			break
+		}
+		if next < span.Start {
+			out.spans = append(out.spans, Span{next, span.Start - 1})
+		}
+		if span.End >= bounds.End {
+			return out // covers the rest of the bounds, which may end at math.MaxInt
+		}
+		next = span.End + 1
+	}
+	if rest := (Span{next, bounds.End}); !rest.Empty() {
+		out.spans = append(out.spans, rest)
+	}
+	return out
+}
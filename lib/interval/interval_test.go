@@ -0,0 +1,184 @@
+package interval_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jstensland/advent-of-code/lib/interval"
+)
+
+func span(start, end int) interval.Span {
+	return interval.Span{Start: start, End: end}
+}
+
+func TestSpan(t *testing.T) {
+	assert.Equal(t, 3, span(4, 6).Len())
+	assert.Equal(t, 0, span(6, 4).Len())
+	assert.True(t, span(6, 4).Empty())
+	assert.True(t, span(4, 6).Contains(6))
+	assert.False(t, span(4, 6).Contains(7))
+	assert.True(t, span(1, 4).Overlaps(span(4, 9)))
+	assert.False(t, span(1, 3).Overlaps(span(4, 9)))
+	assert.False(t, span(1, 9).Overlaps(span(5, 4)))
+}
+
+func TestInsert(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		spans []interval.Span
+		want  []interval.Span
+	}{
+		{desc: "empty", spans: nil, want: nil},
+		{desc: "empty span ignored", spans: []interval.Span{span(5, 1)}, want: nil},
+		{
+			desc:  "sorted",
+			spans: []interval.Span{span(10, 12), span(1, 2), span(5, 6)},
+			want:  []interval.Span{span(1, 2), span(5, 6), span(10, 12)},
+		},
+		{
+			desc:  "overlap merges",
+			spans: []interval.Span{span(1, 5), span(3, 7)},
+			want:  []interval.Span{span(1, 7)},
+		},
+		{
+			desc:  "bridges several",
+			spans: []interval.Span{span(1, 2), span(5, 6), span(9, 10), span(12, 20), span(2, 9)},
+			want:  []interval.Span{span(1, 10), span(12, 20)},
+		},
+		{
+			desc:  "inside existing",
+			spans: []interval.Span{span(1, 10), span(3, 4)},
+			want:  []interval.Span{span(1, 10)},
+		},
+		{
+			desc:  "touching merges",
+			spans: []interval.Span{span(1, 3), span(4, 6)},
+			want:  []interval.Span{span(1, 6)},
+		},
+		{
+			desc:  "touching before merges",
+			spans: []interval.Span{span(4, 6), span(1, 3)},
+			want:  []interval.Span{span(1, 6)},
+		},
+		{
+			desc:  "touching both sides",
+			spans: []interval.Span{span(1, 3), span(7, 9), span(4, 6)},
+			want:  []interval.Span{span(1, 9)},
+		},
+		{
+			desc:  "gap of one kept apart",
+			spans: []interval.Span{span(1, 3), span(5, 6)},
+			want:  []interval.Span{span(1, 3), span(5, 6)},
+		},
+		{
+			desc:  "beyond 32 bits",
+			spans: []interval.Span{span(1<<40, 1<<41), span(1<<35, 1<<40)},
+			want:  []interval.Span{span(1<<35, 1<<41)},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := interval.New(tC.spans...)
+
+			assert.Equal(t, tC.want, got.Spans())
+			assert.Equal(t, tC.want, slices.Collect(got.All()))
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	set := interval.New(span(1, 10), span(20, 30), span(40, 50))
+
+	set.Delete(span(5, 5))
+	assert.Equal(t, []interval.Span{span(1, 4), span(6, 10), span(20, 30), span(40, 50)}, set.Spans())
+
+	set.Delete(span(8, 45))
+	assert.Equal(t, []interval.Span{span(1, 4), span(6, 7), span(46, 50)}, set.Spans())
+
+	set.Delete(span(0, 100))
+	assert.Empty(t, set.Spans())
+}
+
+func TestMembership(t *testing.T) {
+	set := interval.New(span(1, 3), span(4, 6), span(10, 12))
+
+	for _, n := range []int{1, 3, 4, 6, 10, 12} {
+		assert.True(t, set.Contains(n), "%d should be in the set", n)
+	}
+	for _, n := range []int{0, 7, 9, 13} {
+		assert.False(t, set.Contains(n), "%d should not be in the set", n)
+	}
+
+	assert.Equal(t, []interval.Span{span(1, 6), span(10, 12)}, set.Spans(), "touching spans merge")
+	assert.True(t, set.ContainsSpan(span(2, 5)))
+	assert.True(t, set.ContainsSpan(span(10, 12)))
+	assert.True(t, set.ContainsSpan(span(9, 8)), "empty span")
+	assert.False(t, set.ContainsSpan(span(5, 10)))
+	assert.False(t, set.ContainsSpan(span(0, 2)))
+
+	assert.True(t, set.Overlaps(span(6, 9)))
+	assert.True(t, set.Overlaps(span(-5, 100)))
+	assert.False(t, set.Overlaps(span(7, 9)))
+	assert.False(t, set.Overlaps(span(13, 20)))
+
+	assert.Equal(t, 9, set.Len())
+}
+
+func TestSetOperations(t *testing.T) {
+	a := interval.New(span(1, 5), span(10, 15))
+	b := interval.New(span(4, 11), span(14, 20))
+
+	assert.Equal(t, []interval.Span{span(1, 20)}, a.Union(b).Spans())
+	assert.Equal(t, []interval.Span{span(4, 5), span(10, 11), span(14, 15)}, a.Intersect(b).Spans())
+	assert.Empty(t, a.Intersect(interval.New()).Spans())
+
+	assert.Equal(t, []interval.Span{span(0, 0), span(6, 9), span(16, 18)}, a.Complement(span(0, 18)).Spans())
+	assert.Equal(t, []interval.Span{span(6, 9)}, a.Complement(span(3, 12)).Spans())
+	assert.Equal(t, []interval.Span{span(30, 40)}, a.Complement(span(30, 40)).Spans())
+
+	// inputs are unchanged
+	assert.Equal(t, []interval.Span{span(1, 5), span(10, 15)}, a.Spans())
+	assert.Equal(t, []interval.Span{span(4, 11), span(14, 20)}, b.Spans())
+}
+
+func TestZeroValue(t *testing.T) {
+	var set interval.Set
+
+	assert.False(t, set.Contains(0))
+	set.Insert(span(-3, 3))
+	assert.Equal(t, 7, set.Len())
+}
+
+func TestIntLimits(t *testing.T) {
+	low := span(math.MinInt, math.MinInt+2)
+	high := span(math.MaxInt-2, math.MaxInt)
+	set := interval.New(high, low)
+
+	assert.Equal(t, []interval.Span{low, high}, set.Spans(), "the ends don't wrap round and join")
+	assert.True(t, set.Contains(math.MinInt))
+	assert.True(t, set.Contains(math.MaxInt))
+	assert.False(t, set.Contains(0))
+
+	set.Insert(span(math.MinInt+3, -1))
+	assert.Equal(t, []interval.Span{span(math.MinInt, -1), high}, set.Spans(), "touching still joins")
+
+	assert.Equal(t,
+		[]interval.Span{span(math.MinInt, math.MinInt+2), span(math.MaxInt-2, math.MaxInt)},
+		interval.New(span(math.MinInt+3, math.MaxInt-3)).Complement(span(math.MinInt, math.MaxInt)).Spans(),
+	)
+	assert.Equal(t,
+		[]interval.Span{span(0, math.MaxInt-3)},
+		interval.New(high).Complement(span(0, math.MaxInt)).Spans(),
+	)
+	assert.Empty(t, interval.New(span(math.MinInt, math.MaxInt)).Complement(span(math.MinInt, math.MaxInt)).Spans())
+
+	set.Delete(span(math.MinInt, math.MinInt))
+	set.Delete(span(math.MaxInt, math.MaxInt))
+	assert.Equal(t, []interval.Span{span(math.MinInt+1, -1), span(math.MaxInt-2, math.MaxInt-1)}, set.Spans())
+
+	set.Delete(span(math.MinInt, math.MaxInt))
+	assert.Empty(t, set.Spans())
+}
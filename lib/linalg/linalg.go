@@ -0,0 +1,178 @@
+// Package linalg solves systems of linear equations exactly, with big.Rat arithmetic, so puzzles
+// like claw machine button presses can check divisibility without worrying about float error.
+//
+// Systems may be singular. Then rather than a single answer, Solve describes the whole family
+// of solutions as one particular solution plus any combination of basis vectors.
+package linalg
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	// ErrInconsistent is returned when the equations contradict each other, so nothing solves
+	// them.
+	ErrInconsistent = errors.New("inconsistent system")
+	// ErrShape is returned when the matrix rows and right hand side do not line up.
+	ErrShape = errors.New("mismatched system shape")
+)
+
+// Solution is every x with A x = b. Any x = Particular + t1*Basis[0] + t2*Basis[1] + ... works.
+type Solution struct {
+	// Particular solves the system with every free variable set to zero
+	Particular []*big.Rat
+	// Basis spans the null space of A. It is empty when the solution is unique.
+	Basis [][]*big.Rat
+	// Rank of A
+	Rank int
+}
+
+// Unique reports whether Particular is the only solution.
+func (s *Solution) Unique() bool {
+	return len(s.Basis) == 0
+}
+
+// Point is the solution for the given multiples of each basis vector. Missing multiples are
+// zero, so Point() is the particular solution.
+func (s *Solution) Point(multiples ...*big.Rat) []*big.Rat {
+	out := make([]*big.Rat, len(s.Particular))
+	for idx, val := range s.Particular {
+		out[idx] = new(big.Rat).Set(val)
+	}
+	term := new(big.Rat)
+	for vec, mult := range multiples {
+		for idx, val := range s.Basis[vec] {
+			out[idx].Add(out[idx], term.Mul(mult, val))
+		}
+	}
+	return out
+}
+
+// Solve finds every x with A x = b by Gauss-Jordan elimination. A may have any number of rows
+// and columns; it and b are not modified.
+func Solve(a [][]*big.Rat, b []*big.Rat) (*Solution, error) {
+	if len(a) == 0 || len(a) != len(b) {
+		return nil, ErrShape
+	}
+	cols := len(a[0])
+
+	// augmented matrix [A | b]
+	m := make([][]*big.Rat, len(a))
+	for row := range a {
+		if len(a[row]) != cols {
+			return nil, ErrShape
+		}
+		m[row] = make([]*big.Rat, cols+1)
+		for col, val := range a[row] {
+			m[row][col] = new(big.Rat).Set(val)
+		}
+		m[row][cols] = new(big.Rat).Set(b[row])
+	}
+
+	pivots := reduce(m, cols)
+
+	for _, row := range m[len(pivots):] {
+		if row[cols].Sign() != 0 {
+			return nil, ErrInconsistent // 0 = non-zero
+		}
+	}
+
+	sol := &Solution{Particular: zeros(cols), Rank: len(pivots)}
+	isPivot := make([]bool, cols)
+	for row, col := range pivots {
+		sol.Particular[col].Set(m[row][cols])
+		isPivot[col] = true
+	}
+	for free := range cols {
+		if isPivot[free] {
+			continue
+		}
+		vec := zeros(cols)
+		vec[free].SetInt64(1)
+		for row, col := range pivots {
+			vec[col].Neg(m[row][free])
+		}
+		sol.Basis = append(sol.Basis, vec)
+	}
+	return sol, nil
+}
+
+// SolveInts is Solve for a system written with ints.
+func SolveInts(a [][]int, b []int) (*Solution, error) {
+	ra := make([][]*big.Rat, len(a))
+	for row := range a {
+		ra[row] = Rats(a[row]...)
+	}
+	return Solve(ra, Rats(b...))
+}
+
+// Rats converts ints to rationals.
+func Rats(vals ...int) []*big.Rat {
+	out := make([]*big.Rat, len(vals))
+	for idx, val := range vals {
+		out[idx] = big.NewRat(int64(val), 1)
+	}
+	return out
+}
+
+// Ints converts rationals back to ints. It reports false if any of them is not a whole number
+// or does not fit in an int.
+func Ints(vals []*big.Rat) ([]int, bool) {
+	out := make([]int, len(vals))
+	for idx, val := range vals {
+		if !val.IsInt() || !val.Num().IsInt64() {
+			return nil, false
+		}
+		out[idx] = int(val.Num().Int64())
+	}
+	return out, true
+}
+
+// reduce puts the first cols columns of m in reduced row echelon form, in place. It returns the
+// pivot column of each leading row.
+func reduce(m [][]*big.Rat, cols int) []int {
+	pivots := []int{}
+	term := new(big.Rat)
+	for col := 0; col < cols && len(pivots) < len(m); col++ {
+		top := len(pivots)
+		found := -1
+		for row := top; row < len(m); row++ {
+			if m[row][col].Sign() != 0 {
+				found = row
+				break
+			}
+		}
+		if found < 0 {
+			continue // nothing to pivot on, so this variable is free
+		}
+		m[top], m[found] = m[found], m[top]
+
+		// scale so the pivot is one
+		inv := new(big.Rat).Inv(m[top][col])
+		for c := col; c <= cols; c++ {
+			m[top][c].Mul(m[top][c], inv)
+		}
+
+		// clear the column everywhere else
+		for row := range m {
+			if row == top || m[row][col].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(m[row][col])
+			for c := col; c <= cols; c++ {
+				m[row][c].Sub(m[row][c], term.Mul(factor, m[top][c]))
+			}
+		}
+		pivots = append(pivots, col)
+	}
+	return pivots
+}
+
+func zeros(n int) []*big.Rat {
+	out := make([]*big.Rat, n)
+	for idx := range out {
+		out[idx] = new(big.Rat)
+	}
+	return out
+}
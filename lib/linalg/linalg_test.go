@@ -0,0 +1,128 @@
+package linalg_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/lib/linalg"
+)
+
+// check that x solves A x = b
+func check(t *testing.T, a [][]int, b []int, x []*big.Rat) {
+	t.Helper()
+	for row := range a {
+		sum := new(big.Rat)
+		for col, coef := range linalg.Rats(a[row]...) {
+			sum.Add(sum, new(big.Rat).Mul(coef, x[col]))
+		}
+		assert.Equal(t, 0, sum.Cmp(big.NewRat(int64(b[row]), 1)), "row %d: got %v", row, sum)
+	}
+}
+
+func TestSolveInts_Unique(t *testing.T) {
+	a := [][]int{{94, 22}, {34, 67}}
+	b := []int{8400, 5400}
+
+	sol, err := linalg.SolveInts(a, b)
+
+	require.NoError(t, err)
+	assert.True(t, sol.Unique())
+	assert.Equal(t, 2, sol.Rank)
+	got, ok := linalg.Ints(sol.Particular)
+	require.True(t, ok)
+	assert.Equal(t, []int{80, 40}, got)
+}
+
+func TestSolveInts_Fraction(t *testing.T) {
+	// needs a row swap, and the answer is not whole
+	a := [][]int{{0, 1, 1}, {2, 0, 1}, {1, 1, 0}}
+	b := []int{1, 1, 1}
+
+	sol, err := linalg.SolveInts(a, b)
+
+	require.NoError(t, err)
+	assert.True(t, sol.Unique())
+	check(t, a, b, sol.Particular)
+	assert.Equal(t, []*big.Rat{big.NewRat(1, 3), big.NewRat(2, 3), big.NewRat(1, 3)}, sol.Particular)
+	_, ok := linalg.Ints(sol.Particular)
+	assert.False(t, ok)
+}
+
+func TestSolveInts_Family(t *testing.T) {
+	// the second equation is twice the first, so there is a line of answers
+	a := [][]int{{1, 2}, {2, 4}}
+	b := []int{10, 20}
+
+	sol, err := linalg.SolveInts(a, b)
+
+	require.NoError(t, err)
+	assert.False(t, sol.Unique())
+	assert.Equal(t, 1, sol.Rank)
+	require.Len(t, sol.Basis, 1)
+	check(t, a, []int{0, 0}, sol.Basis[0])
+
+	check(t, a, b, sol.Point())
+	for _, mult := range []int64{-3, 1, 7} {
+		check(t, a, b, sol.Point(big.NewRat(mult, 1)))
+	}
+	check(t, a, b, sol.Point(big.NewRat(1, 2)))
+}
+
+func TestSolveInts_Underdetermined(t *testing.T) {
+	a := [][]int{{1, 1, 1, 1}, {0, 1, 0, 1}}
+	b := []int{4, 2}
+
+	sol, err := linalg.SolveInts(a, b)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, sol.Rank)
+	assert.Len(t, sol.Basis, 2)
+	check(t, a, b, sol.Point(big.NewRat(5, 1), big.NewRat(-2, 1)))
+}
+
+func TestSolveInts_Inconsistent(t *testing.T) {
+	_, err := linalg.SolveInts([][]int{{1, 2}, {2, 4}}, []int{10, 21})
+	require.ErrorIs(t, err, linalg.ErrInconsistent)
+
+	// rank zero only works when b is zero too
+	_, err = linalg.SolveInts([][]int{{0, 0}}, []int{1})
+	require.ErrorIs(t, err, linalg.ErrInconsistent)
+}
+
+func TestSolveInts_Shape(t *testing.T) {
+	_, err := linalg.SolveInts([][]int{{1, 2}, {3}}, []int{1, 2})
+	require.ErrorIs(t, err, linalg.ErrShape)
+
+	_, err = linalg.SolveInts([][]int{{1, 2}}, []int{1, 2})
+	require.ErrorIs(t, err, linalg.ErrShape)
+
+	_, err = linalg.SolveInts(nil, nil)
+	require.ErrorIs(t, err, linalg.ErrShape)
+}
+
+func TestSolve_DoesNotModifyInput(t *testing.T) {
+	a := [][]*big.Rat{linalg.Rats(2, 0), linalg.Rats(0, 4)}
+	b := linalg.Rats(2, 4)
+
+	_, err := linalg.Solve(a, b)
+
+	require.NoError(t, err)
+	assert.Equal(t, linalg.Rats(2, 0), a[0])
+	assert.Equal(t, linalg.Rats(2, 4), b)
+}
+
+func TestSolveInts_Large(t *testing.T) {
+	// part 2 of 2024 day 13 pushes products past what fits in an int64
+	a := [][]int{{26, 67}, {66, 21}}
+	b := []int{10000000012748, 10000000012176}
+
+	sol, err := linalg.SolveInts(a, b)
+
+	require.NoError(t, err)
+	got, ok := linalg.Ints(sol.Particular)
+	require.True(t, ok)
+	assert.Equal(t, []int{118679050709, 103199174542}, got)
+}
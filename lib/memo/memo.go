@@ -0,0 +1,79 @@
+// Package memo caches the results of expensive, usually recursive, computations.
+//
+// A Memo belongs to a single computation, like one run over one puzzle input, rather than living
+// in a package variable. That way values from one input can never answer for another.
+package memo
+
+// Memo maps keys to values computed once. The zero value is not usable; use New.
+type Memo[K comparable, V any] struct {
+	values map[K]V
+	hits   int
+	misses int
+}
+
+// Stats counts how often a Memo had the value already.
+type Stats struct {
+	Hits   int
+	Misses int
+}
+
+// HitRate is the fraction of lookups answered from the cache, or 0 before any lookups.
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// New returns an empty Memo.
+func New[K comparable, V any]() *Memo[K, V] {
+	return &Memo[K, V]{values: map[K]V{}}
+}
+
+// Get returns the value for key, calling compute to work it out the first time. compute may call
+// Get again for other keys, which is how recursive functions use a Memo.
+func (m *Memo[K, V]) Get(key K, compute func() V) V {
+	if val, ok := m.values[key]; ok {
+		m.hits++
+		return val
+	}
+	m.misses++
+	val := compute()
+	m.values[key] = val
+	return val
+}
+
+// Lookup returns the cached value for key, if there is one. It does not count towards Stats.
+func (m *Memo[K, V]) Lookup(key K) (V, bool) {
+	val, ok := m.values[key]
+	return val, ok
+}
+
+// Len is how many values are cached.
+func (m *Memo[K, V]) Len() int {
+	return len(m.values)
+}
+
+// Stats reports the hits and misses of Get so far.
+func (m *Memo[K, V]) Stats() Stats {
+	return Stats{Hits: m.hits, Misses: m.misses}
+}
+
+// Reset forgets every cached value and the stats.
+func (m *Memo[K, V]) Reset() {
+	clear(m.values)
+	m.hits, m.misses = 0, 0
+}
+
+// Recursive memoizes fn, which calls recurse instead of itself for sub problems. It returns the
+// memoized function along with its Memo so the caller can inspect it. Each call to Recursive
+// gets a fresh Memo.
+func Recursive[K comparable, V any](fn func(recurse func(K) V, key K) V) (func(K) V, *Memo[K, V]) {
+	m := New[K, V]()
+	var memoized func(K) V
+	memoized = func(key K) V {
+		return m.Get(key, func() V { return fn(memoized, key) })
+	}
+	return memoized, m
+}
@@ -0,0 +1,62 @@
+package memo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jstensland/advent-of-code/lib/memo"
+)
+
+func TestGet(t *testing.T) {
+	m := memo.New[string, int]()
+	calls := 0
+	compute := func() int {
+		calls++
+		return 7
+	}
+
+	assert.Equal(t, 7, m.Get("a", compute))
+	assert.Equal(t, 7, m.Get("a", compute))
+	assert.Equal(t, 7, m.Get("b", compute))
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, memo.Stats{Hits: 1, Misses: 2}, m.Stats())
+	assert.InDelta(t, 1.0/3, m.Stats().HitRate(), 1e-9)
+
+	val, ok := m.Lookup("b")
+	assert.True(t, ok)
+	assert.Equal(t, 7, val)
+	_, ok = m.Lookup("c")
+	assert.False(t, ok)
+}
+
+func TestReset(t *testing.T) {
+	m := memo.New[int, int]()
+	m.Get(1, func() int { return 1 })
+
+	m.Reset()
+
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, memo.Stats{}, m.Stats())
+	assert.Zero(t, m.Stats().HitRate())
+}
+
+func TestRecursive(t *testing.T) {
+	type key struct{ n int }
+	fib, m := memo.Recursive(func(recurse func(key) int, k key) int {
+		if k.n < 2 {
+			return k.n
+		}
+		return recurse(key{k.n - 1}) + recurse(key{k.n - 2})
+	})
+
+	assert.Equal(t, 12586269025, fib(key{50}))
+	assert.Equal(t, 51, m.Len())
+	assert.Equal(t, memo.Stats{Hits: 48, Misses: 51}, m.Stats())
+
+	// a second memoized function does not share values
+	_, other := memo.Recursive(func(func(key) int, key) int { return 0 })
+	assert.Equal(t, 0, other.Len())
+}
@@ -0,0 +1,145 @@
+// Package numeric has number theory helpers for puzzles with periodic or modular structure, like
+// robots that wrap around a grid or dials that count mod 100.
+//
+// Everything works on int. Results that could overflow are checked and reported with
+// ErrOverflow rather than silently wrapping.
+package numeric
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+var (
+	// ErrOverflow is returned when a result does not fit in an int.
+	ErrOverflow = errors.New("integer overflow")
+	// ErrNoInverse is returned when a number shares a factor with the modulus.
+	ErrNoInverse = errors.New("no modular inverse")
+	// ErrNoSolution is returned when congruences contradict each other.
+	ErrNoSolution = errors.New("no solution")
+	// ErrModulus is returned for a modulus that is not positive.
+	ErrModulus = errors.New("modulus must be positive")
+)
+
+// Mod is a mod m in [0, m), unlike %, which keeps the sign of a.
+func Mod(a, m int) int {
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+// GCD is the greatest common divisor of a and b. It is never negative.
+func GCD(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// ExtGCD returns the greatest common divisor g of a and b, along with x and y so that
+// a*x + b*y = g.
+func ExtGCD(a, b int) (int, int, int) {
+	oldR, r := a, b
+	oldX, x := 1, 0
+	oldY, y := 0, 1
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// ModInverse returns x in [0, m) with a*x = 1 mod m.
+func ModInverse(a, m int) (int, error) {
+	if m <= 0 {
+		return 0, ErrModulus
+	}
+	g, x, _ := ExtGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, ErrNoInverse
+	}
+	return Mod(x, m), nil
+}
+
+// Mul is a*b, reporting false if it overflows.
+func Mul(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, false
+	}
+	return c, true
+}
+
+// MulMod is a*b mod m, in [0, m), without overflowing on the way. m must be positive.
+func MulMod(a, b, m int) int {
+	hi, lo := bits.Mul64(uint64(Mod(a, m)), uint64(Mod(b, m))) //nolint:gosec // Mod is non-negative
+	return int(bits.Rem64(hi, lo, uint64(m)))                  //nolint:gosec // less than m
+}
+
+// LCM is the least common multiple of all the values, or 1 for none. Zero values make it zero.
+func LCM(vals ...int) (int, error) {
+	out := 1
+	for _, val := range vals {
+		if val == 0 {
+			return 0, nil
+		}
+		if val < 0 {
+			val = -val
+		}
+		var ok bool
+		out, ok = Mul(out/GCD(out, val), val)
+		if !ok {
+			return 0, ErrOverflow
+		}
+	}
+	return out, nil
+}
+
+// CRT finds x satisfying x = residues[i] mod moduli[i] for every i. The moduli do not need to be
+// coprime. It returns x in [0, m) where m is the LCM of the moduli, since every x + k*m works too.
+func CRT(residues, moduli []int) (int, int, error) {
+	if len(residues) != len(moduli) {
+		return 0, 0, ErrNoSolution
+	}
+	x, m := 0, 1
+	for idx, mod := range moduli {
+		if mod <= 0 {
+			return 0, 0, ErrModulus
+		}
+		r := Mod(residues[idx], mod)
+
+		// x + m*t = r mod mod, so m*t = r-x mod mod, which needs g to divide r-x
+		g := GCD(m, mod)
+		diff := r - Mod(x, mod)
+		if diff%g != 0 {
+			return 0, 0, ErrNoSolution
+		}
+		step := mod / g
+		lcm, ok := Mul(m, step)
+		if !ok {
+			return 0, 0, ErrOverflow
+		}
+		inv, err := ModInverse(m/g, step)
+		if err != nil {
+			return 0, 0, err // impossible after dividing out g
+		}
+		t := MulMod(diff/g, inv, step)
+		x += m * t // x < m and t < step, so this stays under lcm
+		m = lcm
+	}
+	return x, m, nil
+}
@@ -0,0 +1,140 @@
+package numeric_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/lib/numeric"
+)
+
+func TestMod(t *testing.T) {
+	assert.Equal(t, 3, numeric.Mod(13, 10))
+	assert.Equal(t, 7, numeric.Mod(-13, 10))
+	assert.Equal(t, 0, numeric.Mod(-20, 10))
+	assert.Equal(t, 99, numeric.Mod(-1, 100))
+}
+
+func TestGCD(t *testing.T) {
+	assert.Equal(t, 6, numeric.GCD(54, 24))
+	assert.Equal(t, 6, numeric.GCD(-54, 24))
+	assert.Equal(t, 5, numeric.GCD(0, 5))
+	assert.Equal(t, 0, numeric.GCD(0, 0))
+}
+
+func TestExtGCD(t *testing.T) {
+	for _, pair := range [][2]int{{240, 46}, {46, 240}, {-240, 46}, {17, 5}, {0, 7}, {94, 22}} {
+		g, x, y := numeric.ExtGCD(pair[0], pair[1])
+		assert.Equal(t, numeric.GCD(pair[0], pair[1]), g, "%v", pair)
+		assert.Equal(t, g, pair[0]*x+pair[1]*y, "%v", pair)
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	inv, err := numeric.ModInverse(3, 11)
+	require.NoError(t, err)
+	assert.Equal(t, 4, inv)
+
+	inv, err = numeric.ModInverse(-3, 11)
+	require.NoError(t, err)
+	assert.Equal(t, 1, numeric.Mod(-3*inv, 11))
+
+	_, err = numeric.ModInverse(6, 9)
+	require.ErrorIs(t, err, numeric.ErrNoInverse)
+
+	_, err = numeric.ModInverse(3, 0)
+	require.ErrorIs(t, err, numeric.ErrModulus)
+}
+
+func TestMul(t *testing.T) {
+	got, ok := numeric.Mul(1<<31, 1<<31)
+	assert.True(t, ok)
+	assert.Equal(t, 1<<62, got)
+
+	got, ok = numeric.Mul(-7, 6)
+	assert.True(t, ok)
+	assert.Equal(t, -42, got)
+
+	for _, pair := range [][2]int{{1 << 32, 1 << 32}, {math.MaxInt, 2}, {math.MinInt, -1}, {-1, math.MinInt}} {
+		_, ok = numeric.Mul(pair[0], pair[1])
+		assert.False(t, ok, "%v should overflow", pair)
+	}
+}
+
+func TestMulMod(t *testing.T) {
+	assert.Equal(t, 6, numeric.MulMod(7, 8, 10))
+	assert.Equal(t, 4, numeric.MulMod(-7, 8, 10))
+	// 2^62 = 2 mod (2^61 - 1), so the product is 4
+	assert.Equal(t, 4, numeric.MulMod(1<<62, 1<<62, 1<<61-1))
+}
+
+func TestLCM(t *testing.T) {
+	got, err := numeric.LCM(4, 6, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 60, got)
+
+	got, err = numeric.LCM(101, 103)
+	require.NoError(t, err)
+	assert.Equal(t, 10403, got)
+
+	got, err = numeric.LCM()
+	require.NoError(t, err)
+	assert.Equal(t, 1, got)
+
+	got, err = numeric.LCM(3, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, got)
+
+	_, err = numeric.LCM(1<<40+1, 1<<40-1, 7)
+	require.ErrorIs(t, err, numeric.ErrOverflow)
+}
+
+func TestCRT(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		residues []int
+		moduli   []int
+		x, m     int
+		err      error
+	}{
+		{desc: "coprime", residues: []int{2, 3, 2}, moduli: []int{3, 5, 7}, x: 23, m: 105},
+		{desc: "not coprime", residues: []int{3, 5}, moduli: []int{4, 6}, x: 11, m: 12},
+		{desc: "negative residues", residues: []int{-1, -1}, moduli: []int{101, 103}, x: 10402, m: 10403},
+		{desc: "none", residues: nil, moduli: nil, x: 0, m: 1},
+		{desc: "contradiction", residues: []int{1, 2}, moduli: []int{4, 6}, err: numeric.ErrNoSolution},
+		{desc: "bad modulus", residues: []int{1}, moduli: []int{0}, err: numeric.ErrModulus},
+		{desc: "mismatched", residues: []int{1}, moduli: []int{2, 3}, err: numeric.ErrNoSolution},
+		{
+			desc: "overflow", residues: []int{0, 0, 0}, moduli: []int{1<<40 + 1, 1<<40 - 1, 7},
+			err: numeric.ErrOverflow,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			x, m, err := numeric.CRT(tC.residues, tC.moduli)
+			if tC.err != nil {
+				require.ErrorIs(t, err, tC.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tC.x, x)
+			assert.Equal(t, tC.m, m)
+			for idx, mod := range tC.moduli {
+				assert.Equal(t, numeric.Mod(tC.residues[idx], mod), x%mod)
+			}
+		})
+	}
+}
+
+func TestCRT_Large(t *testing.T) {
+	// moduli whose product needs the intermediate products kept in range
+	moduli := []int{1_000_000_007, 998_244_353}
+	x, m, err := numeric.CRT([]int{123_456_789, 987_654_321}, moduli)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1_000_000_007*998_244_353, m)
+	assert.Equal(t, 123_456_789, x%moduli[0])
+	assert.Equal(t, 987_654_321, x%moduli[1])
+}
@@ -0,0 +1,141 @@
+// Package parse turns puzzle input into values. Most inputs are blank line separated sections
+// of lines, each line holding a few numbers in a fixed layout, so the helpers here split
+// sections, pull integers out of text, and decode lines into structs with a regexp.
+//
+// Errors from parsing a line are wrapped in a LineError so a bad input says where it went wrong.
+package parse
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrSections is returned when an input does not have the expected number of sections.
+var ErrSections = errors.New("unexpected number of sections")
+
+// LineError records the 1-based line of the input that failed to parse.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Section is a run of lines from the input, along with where it started so errors can point
+// back at the input.
+type Section struct {
+	// Start is the 1-based line number of the first line
+	Start int
+	Lines []string
+}
+
+// Text joins the lines of the section back together with newlines.
+func (s Section) Text() string {
+	return strings.Join(s.Lines, "\n")
+}
+
+// Sections reads the whole input and splits it on blank lines. Lines made only of whitespace
+// count as blank, runs of blank lines count as one, and leading or trailing blank lines do not
+// produce empty sections.
+func Sections(r io.Reader) ([]Section, error) {
+	var sections []Section
+	var current *Section
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			sections = append(sections, Section{Start: lineNum})
+			current = &sections[len(sections)-1]
+		}
+		current.Lines = append(current.Lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading input: %w", err)
+	}
+	return sections, nil
+}
+
+// SectionsN is Sections for an input that must have exactly n sections.
+func SectionsN(r io.Reader, n int) ([]Section, error) {
+	sections, err := Sections(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(sections) != n {
+		return nil, fmt.Errorf("%w: want %d, got %d", ErrSections, n, len(sections))
+	}
+	return sections, nil
+}
+
+// Map parses every line of the section with fn. Errors are wrapped in a LineError.
+func Map[T any](s Section, fn func(string) (T, error)) ([]T, error) {
+	out := make([]T, 0, len(s.Lines))
+	for idx, line := range s.Lines {
+		val, err := fn(line)
+		if err != nil {
+			return nil, &LineError{Line: s.Start + idx, Err: err}
+		}
+		out = append(out, val)
+	}
+	return out, nil
+}
+
+// Lines parses every non-blank line of the input with fn. Errors are wrapped in a LineError.
+func Lines[T any](r io.Reader, fn func(string) (T, error)) ([]T, error) {
+	sections, err := Sections(r)
+	if err != nil {
+		return nil, err
+	}
+	var out []T
+	for _, section := range sections {
+		vals, err := Map(section, fn)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vals...)
+	}
+	return out, nil
+}
+
+// Ints returns every integer in s, in order. A '-' is read as a sign only when it comes right
+// before a digit and does not follow another digit, so "p=3,-4" is 3 and -4 while a range like
+// "3-4" is 3 and 4.
+func Ints(s string) []int {
+	var out []int
+	for idx := 0; idx < len(s); idx++ {
+		if !isDigit(s[idx]) {
+			continue
+		}
+		start := idx
+		if start > 0 && s[start-1] == '-' && (start < 2 || !isDigit(s[start-2])) {
+			start--
+		}
+		val := 0
+		for ; idx < len(s) && isDigit(s[idx]); idx++ {
+			val = val*10 + int(s[idx]-'0') //nolint:mnd // base 10
+		}
+		if s[start] == '-' {
+			val = -val
+		}
+		out = append(out, val)
+	}
+	return out
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
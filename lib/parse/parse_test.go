@@ -0,0 +1,146 @@
+package parse_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/lib/parse"
+)
+
+func TestSections(t *testing.T) {
+	in := strings.NewReader("\na\nb\n\n\n  \nc\r\n\nd\ne\n\n")
+
+	sections, err := parse.Sections(in)
+
+	require.NoError(t, err)
+	assert.Equal(t, []parse.Section{
+		{Start: 2, Lines: []string{"a", "b"}},
+		{Start: 7, Lines: []string{"c"}},
+		{Start: 9, Lines: []string{"d", "e"}},
+	}, sections)
+	assert.Equal(t, "d\ne", sections[2].Text())
+}
+
+func TestSectionsN(t *testing.T) {
+	_, err := parse.SectionsN(strings.NewReader("a\n\nb"), 3)
+	require.ErrorIs(t, err, parse.ErrSections)
+
+	sections, err := parse.SectionsN(strings.NewReader("a\n\nb"), 2)
+	require.NoError(t, err)
+	assert.Len(t, sections, 2)
+}
+
+func TestLines(t *testing.T) {
+	got, err := parse.Lines(strings.NewReader("1\n2\n\n3\n"), strconv.Atoi)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	_, err = parse.Lines(strings.NewReader("1\n\nx\n"), strconv.Atoi)
+	var lineErr *parse.LineError
+	require.ErrorAs(t, err, &lineErr)
+	assert.Equal(t, 3, lineErr.Line)
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.Contains(t, err.Error(), "line 3:")
+}
+
+func TestInts(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want []int
+	}{
+		{in: "", want: nil},
+		{in: "no numbers", want: nil},
+		{in: "p=0,4 v=3,-3", want: []int{0, 4, 3, -3}},
+		{in: "Button A: X+94, Y+34", want: []int{94, 34}},
+		{in: "3-5", want: []int{3, 5}},
+		{in: "-12 x-7 --8", want: []int{-12, -7, -8}},
+		{in: "Program: 0,1,5,4,3,0", want: []int{0, 1, 5, 4, 3, 0}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.in, func(t *testing.T) {
+			assert.Equal(t, tC.want, parse.Ints(tC.in))
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	n, err := parse.Value[int](" -42 ")
+	require.NoError(t, err)
+	assert.Equal(t, -42, n)
+
+	list, err := parse.Value[[]uint8]("0,1,5, 4")
+	require.NoError(t, err)
+	assert.Equal(t, []uint8{0, 1, 5, 4}, list)
+
+	_, err = parse.Value[uint8]("300")
+	require.ErrorIs(t, err, strconv.ErrRange)
+
+	_, err = parse.Value[map[int]int]("1")
+	require.ErrorIs(t, err, parse.ErrUnsupported)
+}
+
+type button struct {
+	Name string
+	X, Y int
+}
+
+func TestRecord_Positional(t *testing.T) {
+	rec := parse.MustRecord[button](`Button (\w): X\+(\d+), Y\+(\d+)`)
+
+	got, err := rec.Decode("Button A: X+94, Y+34")
+	require.NoError(t, err)
+	assert.Equal(t, button{Name: "A", X: 94, Y: 34}, got)
+
+	_, err = rec.Decode("Prize: X=8400, Y=5400")
+	require.ErrorIs(t, err, parse.ErrNoMatch)
+}
+
+func TestRecord_Named(t *testing.T) {
+	rec := parse.MustRecord[button](`(?P<Y>\d+),(?P<X>\d+)`)
+
+	got, err := rec.Decode("4,3")
+
+	require.NoError(t, err)
+	assert.Equal(t, button{X: 3, Y: 4}, got)
+}
+
+func TestRecord_DecodeSection(t *testing.T) {
+	type registers struct {
+		A, B    int
+		Program []uint8
+	}
+	rec := parse.MustRecord[registers](`A: (\d+)\nB: (\d+)\nProgram: (.*)`)
+	sections, err := parse.Sections(strings.NewReader("A: 1\nB: 2\nProgram: 0,3\n\nA: 1\nB: x\nProgram: 0"))
+	require.NoError(t, err)
+
+	got, err := rec.DecodeSection(sections[0])
+	require.NoError(t, err)
+	assert.Equal(t, registers{A: 1, B: 2, Program: []uint8{0, 3}}, got)
+
+	_, err = rec.DecodeSection(sections[1])
+	var lineErr *parse.LineError
+	require.ErrorAs(t, err, &lineErr)
+	assert.Equal(t, 5, lineErr.Line)
+}
+
+func TestRecord_FieldError(t *testing.T) {
+	rec := parse.MustRecord[button](`(\w+) (\S+)`)
+
+	_, err := rec.Decode("A 9999999999999999999999")
+
+	require.ErrorIs(t, err, strconv.ErrRange)
+	assert.Contains(t, err.Error(), "field X")
+}
+
+func TestMustRecord_Panics(t *testing.T) {
+	assert.Panics(t, func() { parse.MustRecord[button](`(a)(b)(c)(d)`) }, "too many groups")
+	assert.Panics(t, func() { parse.MustRecord[button](`(?P<Z>\d+)`) }, "unknown field")
+	assert.Panics(t, func() { parse.MustRecord[int](`(\d+)`) }, "not a struct")
+	assert.Panics(t, func() {
+		parse.MustRecord[struct{ M map[int]int }](`(\d+)`)
+	}, "unsupported field")
+}
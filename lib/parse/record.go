@@ -0,0 +1,165 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNoMatch is returned when text does not match a Record's pattern.
+	ErrNoMatch = errors.New("no match")
+	// ErrUnsupported is returned for a type Value does not know how to parse.
+	ErrUnsupported = errors.New("unsupported type")
+)
+
+// Record decodes text into a struct of type T with a regular expression. Each capture group
+// fills one exported field: named groups fill the field of the same name, otherwise groups fill
+// the exported fields in order. Fields are parsed like Value.
+type Record[T any] struct {
+	re     *regexp.Regexp
+	fields []int // struct field index for each capture group
+}
+
+// MustRecord compiles the pattern for T. Like regexp.MustCompile, it panics if the pattern is
+// invalid, or if the capture groups do not line up with parsable fields of T.
+func MustRecord[T any](pattern string) *Record[T] {
+	re := regexp.MustCompile(pattern)
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("parse: record type %v is not a struct", typ))
+	}
+
+	exported := []int{}
+	for idx := range typ.NumField() {
+		field := typ.Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+		if !supported(field.Type) {
+			panic(fmt.Sprintf("parse: field %s of %v: %v", field.Name, typ, ErrUnsupported))
+		}
+		exported = append(exported, idx)
+	}
+
+	names := re.SubexpNames()[1:]
+	fields := make([]int, len(names))
+	for group, name := range names {
+		if name == "" {
+			if group >= len(exported) {
+				panic(fmt.Sprintf("parse: %d capture groups but %v has %d fields", len(names), typ, len(exported)))
+			}
+			fields[group] = exported[group]
+			continue
+		}
+		field, ok := typ.FieldByName(name)
+		if !ok || !field.IsExported() || len(field.Index) != 1 {
+			panic(fmt.Sprintf("parse: group %q has no matching field in %v", name, typ))
+		}
+		fields[group] = field.Index[0]
+	}
+	return &Record[T]{re: re, fields: fields}
+}
+
+// Decode fills a T from the first match of the pattern in s.
+func (r *Record[T]) Decode(s string) (T, error) {
+	var out T
+	match := r.re.FindStringSubmatch(s)
+	if match == nil {
+		return out, fmt.Errorf("%w: %q for %s", ErrNoMatch, s, r.re)
+	}
+
+	val := reflect.ValueOf(&out).Elem()
+	for group, field := range r.fields {
+		if err := set(val.Field(field), match[group+1]); err != nil {
+			return out, fmt.Errorf("field %s: %w", val.Type().Field(field).Name, err)
+		}
+	}
+	return out, nil
+}
+
+// DecodeSection decodes the whole section as one record, so the pattern may span lines.
+// Errors are wrapped in a LineError for the start of the section.
+func (r *Record[T]) DecodeSection(s Section) (T, error) {
+	out, err := r.Decode(s.Text())
+	if err != nil {
+		return out, &LineError{Line: s.Start, Err: err}
+	}
+	return out, nil
+}
+
+// Value parses s, ignoring surrounding spaces, as a T. Integers, floats, bools and strings are
+// supported, along with slices of them written as comma separated lists. Integers are range
+// checked, so "300" is an error for a uint8.
+func Value[T any](s string) (T, error) {
+	var out T
+	err := set(reflect.ValueOf(&out).Elem(), s)
+	return out, err
+}
+
+func supported(typ reflect.Type) bool {
+	switch typ.Kind() { //nolint:exhaustive // everything else is unsupported
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	case reflect.Slice:
+		return typ.Elem().Kind() != reflect.Slice && supported(typ.Elem())
+	default:
+		return false
+	}
+}
+
+func set(val reflect.Value, s string) error {
+	s = strings.TrimSpace(s)
+	switch val.Kind() { //nolint:exhaustive // everything else is unsupported
+	case reflect.String:
+		val.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		val.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetFloat(f)
+	case reflect.Slice:
+		if !supported(val.Type()) {
+			return fmt.Errorf("%w: %v", ErrUnsupported, val.Type())
+		}
+		if s == "" {
+			val.Set(reflect.MakeSlice(val.Type(), 0, 0))
+			return nil
+		}
+		parts := strings.Split(s, ",")
+		list := reflect.MakeSlice(val.Type(), len(parts), len(parts))
+		for idx, part := range parts {
+			if err := set(list.Index(idx), part); err != nil {
+				return fmt.Errorf("item %d: %w", idx, err)
+			}
+		}
+		val.Set(list)
+	default:
+		return fmt.Errorf("%w: %v", ErrUnsupported, val.Type())
+	}
+	return nil
+}
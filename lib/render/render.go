@@ -0,0 +1,164 @@
+// Package render draws grids as images, so simulations can be looked at as a PNG of one moment
+// or an animated GIF of the whole run rather than a wall of String() output.
+//
+// Each cell becomes a square of pixels coloured by a Palette.
+package render
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/jstensland/advent-of-code/lib/grid"
+)
+
+// maxColors is the most a GIF frame can hold.
+const maxColors = 256
+
+// ErrTooManyColors is returned for a palette with more colours than an image can index.
+var ErrTooManyColors = errors.New("too many colors")
+
+// Palette colours cells. Cells missing from Colors are drawn in Default, or black if that is
+// nil too.
+type Palette[T comparable] struct {
+	Colors  map[T]color.Color
+	Default color.Color
+}
+
+// Color is the colour to draw the cell.
+func (p Palette[T]) Color(cell T) color.Color {
+	if c, ok := p.Colors[cell]; ok {
+		return c
+	}
+	return p.fallback()
+}
+
+func (p Palette[T]) fallback() color.Color {
+	if p.Default != nil {
+		return p.Default
+	}
+	return color.Black
+}
+
+// colors lists every distinct colour in the palette in a fixed order, so the same grid always
+// encodes to the same bytes.
+func (p Palette[T]) colors() (color.Palette, error) {
+	rgba := []color.RGBA{color.RGBAModel.Convert(p.fallback()).(color.RGBA)}
+	for c := range maps.Values(p.Colors) {
+		rgba = append(rgba, color.RGBAModel.Convert(c).(color.RGBA))
+	}
+	slices.SortFunc(rgba, func(a, b color.RGBA) int {
+		return cmp.Or(cmp.Compare(a.R, b.R), cmp.Compare(a.G, b.G), cmp.Compare(a.B, b.B), cmp.Compare(a.A, b.A))
+	})
+	rgba = slices.Compact(rgba)
+	if len(rgba) > maxColors {
+		return nil, fmt.Errorf("%w: %d", ErrTooManyColors, len(rgba))
+	}
+
+	out := make(color.Palette, len(rgba))
+	for idx, c := range rgba {
+		out[idx] = c
+	}
+	return out, nil
+}
+
+// Image draws the grid with each cell as a scale by scale square.
+func Image[T comparable](g *grid.Grid[T], p Palette[T], scale int) (*image.Paletted, error) {
+	colors, err := p.colors()
+	if err != nil {
+		return nil, err
+	}
+	return draw(g, p, colors, scale), nil
+}
+
+func draw[T comparable](g *grid.Grid[T], p Palette[T], colors color.Palette, scale int) *image.Paletted {
+	scale = max(scale, 1)
+	img := image.NewPaletted(image.Rect(0, 0, g.Width()*scale, g.Height()*scale), colors)
+	index := map[T]uint8{}
+	for pos, cell := range g.All() {
+		idx, ok := index[cell]
+		if !ok {
+			idx = uint8(colors.Index(p.Color(cell))) //nolint:gosec // at most maxColors
+			index[cell] = idx
+		}
+		for row := pos.Row * scale; row < (pos.Row+1)*scale; row++ {
+			for col := pos.Col * scale; col < (pos.Col+1)*scale; col++ {
+				img.SetColorIndex(col, row, idx)
+			}
+		}
+	}
+	return img
+}
+
+// WritePNG draws the grid as a PNG.
+func WritePNG[T comparable](w io.Writer, g *grid.Grid[T], p Palette[T], scale int) error {
+	img, err := Image(g, p, scale)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("error encoding png: %w", err)
+	}
+	return nil
+}
+
+// Recorder collects frames of a simulation into an animated GIF.
+type Recorder[T comparable] struct {
+	palette Palette[T]
+	colors  color.Palette
+	scale   int
+	delay   int
+	anim    gif.GIF
+}
+
+// NewRecorder returns a Recorder drawing cells as scale by scale squares, showing each frame
+// for delay hundredths of a second.
+func NewRecorder[T comparable](p Palette[T], scale, delay int) (*Recorder[T], error) {
+	colors, err := p.colors()
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder[T]{palette: p, colors: colors, scale: scale, delay: delay}, nil
+}
+
+// Frame adds the grid as it is now.
+func (r *Recorder[T]) Frame(g *grid.Grid[T]) {
+	r.anim.Image = append(r.anim.Image, draw(g, r.palette, r.colors, r.scale))
+	r.anim.Delay = append(r.anim.Delay, r.delay)
+}
+
+// Record adds a frame of the starting state, then calls step and adds a frame after each call,
+// until step returns false or it has taken maxSteps steps. It returns the number of frames
+// added.
+func (r *Recorder[T]) Record(snapshot func() *grid.Grid[T], step func() bool, maxSteps int) int {
+	r.Frame(snapshot())
+	frames := 1
+	for range maxSteps {
+		if !step() {
+			break
+		}
+		r.Frame(snapshot())
+		frames++
+	}
+	return frames
+}
+
+// Frames is how many frames have been added.
+func (r *Recorder[T]) Frames() int {
+	return len(r.anim.Image)
+}
+
+// WriteGIF writes every frame so far as a looping GIF.
+func (r *Recorder[T]) WriteGIF(w io.Writer) error {
+	if err := gif.EncodeAll(w, &r.anim); err != nil {
+		return fmt.Errorf("error encoding gif: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package render_test
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/lib/grid"
+	"github.com/jstensland/advent-of-code/lib/render"
+)
+
+func palette() render.Palette[rune] {
+	return render.Palette[rune]{
+		Colors: map[rune]color.Color{
+			'#': color.White,
+			'@': color.RGBA{R: 255, A: 255},
+		},
+	}
+}
+
+func example(t *testing.T) *grid.Grid[rune] {
+	t.Helper()
+	g, err := grid.Parse(strings.NewReader("#.#\n.@."), grid.Runes)
+	require.NoError(t, err)
+	return g
+}
+
+func rgba(c color.Color) color.RGBA {
+	return color.RGBAModel.Convert(c).(color.RGBA)
+}
+
+func TestImage(t *testing.T) {
+	img, err := render.Image(example(t), palette(), 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, 6, img.Bounds().Dx())
+	assert.Equal(t, 4, img.Bounds().Dy())
+	assert.Len(t, img.Palette, 3)
+	// each cell fills a 2x2 square
+	for _, px := range [][2]int{{0, 0}, {1, 1}, {4, 0}, {5, 1}} {
+		assert.Equal(t, rgba(color.White), rgba(img.At(px[0], px[1])), "%v", px)
+	}
+	assert.Equal(t, rgba(color.Black), rgba(img.At(2, 0)), "missing cells use the default")
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, rgba(img.At(3, 3)))
+}
+
+func TestImage_Default(t *testing.T) {
+	p := palette()
+	p.Default = color.RGBA{B: 255, A: 255}
+
+	img, err := render.Image(example(t), p, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, color.RGBA{B: 255, A: 255}, rgba(img.At(1, 0)))
+}
+
+func TestImage_TooManyColors(t *testing.T) {
+	p := render.Palette[int]{Colors: map[int]color.Color{}}
+	for i := range 300 {
+		p.Colors[i] = color.RGBA{R: uint8(i % 256), G: uint8(i / 256), A: 255}
+	}
+
+	_, err := render.Image(grid.New[int](1, 1), p, 1)
+
+	require.ErrorIs(t, err, render.ErrTooManyColors)
+}
+
+func TestWritePNG(t *testing.T) {
+	var buf, again bytes.Buffer
+	require.NoError(t, render.WritePNG(&buf, example(t), palette(), 3))
+	require.NoError(t, render.WritePNG(&again, example(t), palette(), 3))
+	assert.Equal(t, buf.Bytes(), again.Bytes(), "the same grid always encodes the same way")
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, 9, img.Bounds().Dx())
+	assert.Equal(t, rgba(color.White), rgba(img.At(0, 0)))
+}
+
+func TestRecorder(t *testing.T) {
+	g := example(t)
+	rec, err := render.NewRecorder(palette(), 1, 5)
+	require.NoError(t, err)
+
+	// walk the robot right until it hits the edge
+	col := 1
+	frames := rec.Record(func() *grid.Grid[rune] { return g }, func() bool {
+		if col == g.Width()-1 {
+			return false
+		}
+		g.Set(grid.Pos{Row: 1, Col: col}, '.')
+		col++
+		g.Set(grid.Pos{Row: 1, Col: col}, '@')
+		return true
+	}, 10)
+
+	assert.Equal(t, 2, frames)
+	assert.Equal(t, 2, rec.Frames())
+
+	var buf bytes.Buffer
+	require.NoError(t, rec.WriteGIF(&buf))
+	anim, err := gif.DecodeAll(&buf)
+	require.NoError(t, err)
+	require.Len(t, anim.Image, 2)
+	assert.Equal(t, []int{5, 5}, anim.Delay)
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, rgba(anim.Image[0].At(1, 1)))
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, rgba(anim.Image[1].At(2, 1)))
+	assert.Equal(t, rgba(color.Black), rgba(anim.Image[1].At(1, 1)))
+}
+
+func TestRecorder_MaxSteps(t *testing.T) {
+	rec, err := render.NewRecorder(palette(), 1, 1)
+	require.NoError(t, err)
+
+	frames := rec.Record(func() *grid.Grid[rune] { return example(t) }, func() bool { return true }, 3)
+
+	assert.Equal(t, 4, frames)
+}
@@ -0,0 +1,209 @@
+// Package shortest finds least cost paths through weighted graphs, keeping every path that
+// ties for the best cost rather than just the first one found.
+//
+// States can be anything comparable, like a position and the direction faced. The graph is
+// never built up front. Instead a neighbors callback lists the moves out of each state and
+// what they cost, so huge or infinite graphs are only explored as far as needed. Costs must
+// not be negative.
+package shortest
+
+import (
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+// Neighbors lists the states reachable in one move from a state, and the cost of each move.
+type Neighbors[S comparable] func(S) iter.Seq2[S, int]
+
+// Dijkstra searches outward from the starts until every goal tied for the least cost has
+// been reached. With a nil isGoal it explores every reachable state.
+func Dijkstra[S comparable](starts []S, neighbors Neighbors[S], isGoal func(S) bool) *Result[S] {
+	return AStar(starts, neighbors, isGoal, nil)
+}
+
+// AStar is Dijkstra guided by a heuristic estimate of the remaining cost to a goal. The
+// heuristic must never overestimate, or step down by more than a move costs, otherwise
+// optimal paths may be missed. A nil heuristic is plain Dijkstra.
+func AStar[S comparable](starts []S, neighbors Neighbors[S], isGoal func(S) bool, heuristic func(S) int) *Result[S] {
+	if heuristic == nil {
+		heuristic = func(S) int { return 0 }
+	}
+	res := &Result[S]{
+		dist: map[S]int{},
+		prev: map[S][]S{},
+	}
+	queue := &queue[S]{}
+	isStart := map[S]bool{}
+	for _, start := range starts {
+		isStart[start] = true
+		res.dist[start] = 0
+		heap.Push(queue, item[S]{state: start, cost: 0, priority: heuristic(start)})
+	}
+
+	best := -1
+	done := map[S]bool{}
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(item[S])
+		if best >= 0 && current.priority > best {
+			break // everything left costs more than the goals already found
+		}
+		if done[current.state] || current.cost > res.dist[current.state] {
+			continue // already settled for less
+		}
+		done[current.state] = true
+
+		if isGoal != nil && isGoal(current.state) {
+			best = current.cost
+			res.goals = append(res.goals, current.state)
+			// keep going, as a zero cost move can reach another goal just as cheaply
+		}
+
+		for next, cost := range neighbors(current.state) {
+			nextCost := current.cost + cost
+			known, seen := res.dist[next]
+			switch {
+			case !seen || nextCost < known:
+				res.dist[next] = nextCost
+				res.prev[next] = []S{current.state}
+				heap.Push(queue, item[S]{state: next, cost: nextCost, priority: nextCost + heuristic(next)})
+			case nextCost == known && !isStart[next] && !slices.Contains(res.prev[next], current.state):
+				if cost == 0 && res.leadsTo(next, current.state) {
+					continue // a zero cost loop back, which would make the paths go round forever
+				}
+				res.prev[next] = append(res.prev[next], current.state) // another way to get there, just as cheap
+			}
+		}
+	}
+	return res
+}
+
+// Result holds what a search learned: the least cost to each state it reached, and every
+// predecessor that gets there at that cost.
+type Result[S comparable] struct {
+	dist  map[S]int
+	prev  map[S][]S
+	goals []S
+}
+
+// Goals returns the goal states reached at the least cost, in the order they were found.
+// It's empty if no goal could be reached.
+func (r *Result[S]) Goals() []S { return r.goals }
+
+// BestCost returns the cost of reaching the cheapest goal, and false if none was reached.
+func (r *Result[S]) BestCost() (int, bool) {
+	if len(r.goals) == 0 {
+		return 0, false
+	}
+	return r.dist[r.goals[0]], true
+}
+
+// Cost returns the least cost found to a state, and false if it wasn't reached.
+//
+// Only goals and the states leading to them are guaranteed to be the true least cost. Others
+// are the best seen before the search stopped.
+func (r *Result[S]) Cost(s S) (int, bool) {
+	cost, ok := r.dist[s]
+	return cost, ok
+}
+
+// Predecessors returns every state with a least cost move to s. Starts have none, and zero
+// cost moves that would loop back are left out, so following predecessors always ends at a
+// start.
+func (r *Result[S]) Predecessors(s S) []S { return r.prev[s] }
+
+// Path returns one least cost path from a start to the given state, or nil if it wasn't
+// reached.
+func (r *Result[S]) Path(to S) []S {
+	if _, ok := r.dist[to]; !ok {
+		return nil
+	}
+	path := []S{to}
+	for prev := r.prev[to]; len(prev) > 0; prev = r.prev[prev[0]] {
+		path = append(path, prev[0])
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// AllPaths iterates over every least cost path from a start to the given state. There can
+// be exponentially many, so prefer OnBestPaths when only the states matter.
+func (r *Result[S]) AllPaths(to S) iter.Seq[[]S] {
+	return func(yield func([]S) bool) {
+		if _, ok := r.dist[to]; !ok {
+			return
+		}
+		r.walkBack([]S{to}, yield)
+	}
+}
+
+// walkBack extends the reversed path back to every start, yielding each complete path.
+func (r *Result[S]) walkBack(reversed []S, yield func([]S) bool) bool {
+	prevs := r.prev[reversed[len(reversed)-1]]
+	if len(prevs) == 0 {
+		path := slices.Clone(reversed)
+		slices.Reverse(path)
+		return yield(path)
+	}
+	for _, prev := range prevs {
+		if !r.walkBack(append(reversed, prev), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// leadsTo returns true if from is on a least cost path to s found so far.
+func (r *Result[S]) leadsTo(from, s S) bool {
+	seen := map[S]bool{}
+	stack := []S{s}
+	for len(stack) > 0 {
+		state := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if state == from {
+			return true
+		}
+		if seen[state] {
+			continue
+		}
+		seen[state] = true
+		stack = append(stack, r.prev[state]...)
+	}
+	return false
+}
+
+// OnBestPaths returns every state on any least cost path to any of the goals found.
+func (r *Result[S]) OnBestPaths() map[S]bool {
+	seen := map[S]bool{}
+	stack := slices.Clone(r.goals)
+	for len(stack) > 0 {
+		state := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[state] {
+			continue
+		}
+		seen[state] = true
+		stack = append(stack, r.prev[state]...)
+	}
+	return seen
+}
+
+type item[S any] struct {
+	state    S
+	cost     int
+	priority int
+}
+
+// queue is a min heap of items by priority.
+type queue[S any] []item[S]
+
+func (q queue[S]) Len() int           { return len(q) }
+func (q queue[S]) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q queue[S]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *queue[S]) Push(x any)        { *q = append(*q, x.(item[S])) }
+func (q *queue[S]) Pop() any {
+	old := *q
+	last := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return last
+}
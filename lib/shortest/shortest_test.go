@@ -0,0 +1,197 @@
+package shortest_test
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/lib/geom"
+	"github.com/jstensland/advent-of-code/lib/grid"
+	"github.com/jstensland/advent-of-code/lib/shortest"
+)
+
+// diamond has two equally cheap ways from a to d, and a more expensive direct edge.
+//
+//	a -1-> b -2-> d
+//	a -2-> c -1-> d
+//	a -5-> d
+func diamond() shortest.Neighbors[string] {
+	edges := map[string]map[string]int{
+		"a": {"b": 1, "c": 2, "d": 5},
+		"b": {"d": 2},
+		"c": {"d": 1},
+	}
+	return func(s string) iter.Seq2[string, int] {
+		return maps.All(edges[s])
+	}
+}
+
+func is(goal string) func(string) bool {
+	return func(s string) bool { return s == goal }
+}
+
+func TestDijkstra_Ties(t *testing.T) {
+	res := shortest.Dijkstra([]string{"a"}, diamond(), is("d"))
+
+	best, ok := res.BestCost()
+	require.True(t, ok)
+	assert.Equal(t, 3, best)
+	assert.Equal(t, []string{"d"}, res.Goals())
+	assert.ElementsMatch(t, []string{"b", "c"}, res.Predecessors("d"))
+
+	path := res.Path("d")
+	assert.Len(t, path, 3)
+	assert.Equal(t, "a", path[0])
+	assert.Equal(t, "d", path[2])
+
+	assert.ElementsMatch(t,
+		[][]string{{"a", "b", "d"}, {"a", "c", "d"}},
+		slices.Collect(res.AllPaths("d")),
+	)
+	assert.Equal(t, map[string]bool{"a": true, "b": true, "c": true, "d": true}, res.OnBestPaths())
+}
+
+func TestDijkstra_Unreachable(t *testing.T) {
+	res := shortest.Dijkstra([]string{"b"}, diamond(), is("a"))
+
+	_, ok := res.BestCost()
+	assert.False(t, ok)
+	assert.Empty(t, res.Goals())
+	assert.Nil(t, res.Path("a"))
+	assert.Empty(t, slices.Collect(res.AllPaths("a")))
+	assert.Empty(t, res.OnBestPaths())
+}
+
+func TestDijkstra_ExploreAll(t *testing.T) {
+	res := shortest.Dijkstra([]string{"a"}, diamond(), nil)
+
+	for state, want := range map[string]int{"a": 0, "b": 1, "c": 2, "d": 3} {
+		cost, ok := res.Cost(state)
+		assert.True(t, ok)
+		assert.Equal(t, want, cost, "cost to %s", state)
+	}
+	assert.Empty(t, res.Goals())
+}
+
+func TestDijkstra_MultipleStarts(t *testing.T) {
+	res := shortest.Dijkstra([]string{"a", "c"}, diamond(), is("d"))
+
+	best, ok := res.BestCost()
+	require.True(t, ok)
+	assert.Equal(t, 1, best)
+	assert.Equal(t, []string{"c", "d"}, res.Path("d"))
+}
+
+func maze() string {
+	return `S...#....
+.##.#.##.
+.#..#..#.
+.#.###.#.
+...#...#G
+.#...#...`
+}
+
+func mazeSearch(t testing.TB) (shortest.Neighbors[grid.Pos], grid.Pos, grid.Pos) {
+	t.Helper()
+	g, err := grid.Parse(strings.NewReader(maze()), grid.Runes)
+	require.NoError(t, err)
+	start, _ := grid.Find(g, 'S')
+	goal, _ := grid.Find(g, 'G')
+
+	neighbors := func(p grid.Pos) iter.Seq2[grid.Pos, int] {
+		return func(yield func(grid.Pos, int) bool) {
+			for next := range g.Neighbors4(p) {
+				if g.At(next) != '#' && !yield(next, 1) {
+					return
+				}
+			}
+		}
+	}
+	return neighbors, start, goal
+}
+
+func TestAStar_MatchesDijkstra(t *testing.T) {
+	neighbors, start, goal := mazeSearch(t)
+	isGoal := func(p grid.Pos) bool { return p == goal }
+
+	dijkstra := shortest.Dijkstra([]grid.Pos{start}, neighbors, isGoal)
+	astar := shortest.AStar([]grid.Pos{start}, neighbors, isGoal, func(p grid.Pos) int {
+		return geom.Manhattan(p, goal)
+	})
+
+	want, ok := dijkstra.BestCost()
+	require.True(t, ok)
+	assert.Equal(t, 16, want)
+	got, ok := astar.BestCost()
+	require.True(t, ok)
+	assert.Equal(t, want, got)
+
+	assert.Equal(t, dijkstra.OnBestPaths(), astar.OnBestPaths())
+	assert.Len(t, astar.Path(goal), want+1)
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	neighbors, start, goal := mazeSearch(b)
+	for b.Loop() {
+		shortest.Dijkstra([]grid.Pos{start}, neighbors, func(p grid.Pos) bool { return p == goal })
+	}
+}
+
+func TestDijkstra_ZeroCostBetweenGoals(t *testing.T) {
+	// a -1-> g1 -0-> g2, with both goals costing 1
+	edges := map[string]map[string]int{
+		"a":  {"g1": 1},
+		"g1": {"g2": 0},
+	}
+	neighbors := func(s string) iter.Seq2[string, int] { return maps.All(edges[s]) }
+
+	res := shortest.Dijkstra([]string{"a"}, neighbors, func(s string) bool { return strings.HasPrefix(s, "g") })
+
+	best, ok := res.BestCost()
+	require.True(t, ok)
+	assert.Equal(t, 1, best)
+	assert.Equal(t, []string{"g1", "g2"}, res.Goals())
+	assert.Equal(t, []string{"g1"}, res.Predecessors("g2"))
+	assert.Equal(t, []string{"a", "g1", "g2"}, res.Path("g2"))
+}
+
+func TestDijkstra_ZeroCostLoopThroughStart(t *testing.T) {
+	// a -0-> b -0-> a, and b -1-> g
+	edges := map[string]map[string]int{
+		"a": {"b": 0},
+		"b": {"a": 0, "g": 1},
+	}
+	neighbors := func(s string) iter.Seq2[string, int] { return maps.All(edges[s]) }
+
+	res := shortest.Dijkstra([]string{"a"}, neighbors, is("g"))
+
+	assert.Empty(t, res.Predecessors("a"), "the start has no way in")
+	assert.Equal(t, []string{"a", "b", "g"}, res.Path("g"))
+	assert.Equal(t, [][]string{{"a", "b", "g"}}, slices.Collect(res.AllPaths("g")))
+}
+
+func TestDijkstra_ZeroCostLoop(t *testing.T) {
+	// s -1-> x and s -1-> y, with x and y joined both ways for free
+	edges := map[string]map[string]int{
+		"s": {"x": 1, "y": 1},
+		"x": {"y": 0, "g": 1},
+		"y": {"x": 0},
+	}
+	neighbors := func(s string) iter.Seq2[string, int] { return maps.All(edges[s]) }
+
+	res := shortest.Dijkstra([]string{"s"}, neighbors, is("g"))
+
+	// whichever of x and y is settled first can lead to the other, but not both ways
+	paths := slices.Collect(res.AllPaths("g"))
+	assert.Contains(t, paths, []string{"s", "x", "g"})
+	assert.LessOrEqual(t, len(paths), 2)
+	for _, path := range paths {
+		assert.Len(t, slices.Compact(slices.Sorted(slices.Values(path))), len(path), "no state twice in %v", path)
+	}
+	assert.Equal(t, "g", res.Path("g")[len(res.Path("g"))-1])
+}
@@ -0,0 +1,148 @@
+// Package unionfind tracks items partitioned into disjoint sets, like circuits of connected
+// junction boxes or regions of matching plots, as the sets are merged together.
+//
+// Find uses path compression and Union merges the smaller set into the larger, so each
+// operation is close to constant time no matter how many items there are.
+package unionfind
+
+import (
+	"cmp"
+	"slices"
+)
+
+// UnionFind is a collection of disjoint sets of items. The zero value is not usable; use New.
+type UnionFind[T comparable] struct {
+	index  map[T]int // item to its slot in the slices below
+	items  []T
+	parent []int
+	size   []int // only meaningful for roots
+	count  int   // number of sets
+}
+
+// New returns a UnionFind with each of the items in a set of its own.
+func New[T comparable](items ...T) *UnionFind[T] {
+	uf := &UnionFind[T]{
+		index:  make(map[T]int, len(items)),
+		items:  make([]T, 0, len(items)),
+		parent: make([]int, 0, len(items)),
+		size:   make([]int, 0, len(items)),
+	}
+	for _, item := range items {
+		uf.Add(item)
+	}
+	return uf
+}
+
+// Add puts the item in a set of its own. It returns false, changing nothing, if the item was
+// already there.
+func (uf *UnionFind[T]) Add(item T) bool {
+	if _, ok := uf.index[item]; ok {
+		return false
+	}
+	idx := len(uf.items)
+	uf.index[item] = idx
+	uf.items = append(uf.items, item)
+	uf.parent = append(uf.parent, idx)
+	uf.size = append(uf.size, 1)
+	uf.count++
+	return true
+}
+
+// Contains returns true if the item has been added.
+func (uf *UnionFind[T]) Contains(item T) bool {
+	_, ok := uf.index[item]
+	return ok
+}
+
+// Find returns the item representing the set that item is in, and false if the item was
+// never added. Two items are in the same set exactly when they have the same representative.
+func (uf *UnionFind[T]) Find(item T) (T, bool) {
+	idx, ok := uf.index[item]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return uf.items[uf.root(idx)], true
+}
+
+// root follows parents to the root, pointing everything on the way directly at it.
+func (uf *UnionFind[T]) root(idx int) int {
+	root := idx
+	for uf.parent[root] != root {
+		root = uf.parent[root]
+	}
+	for uf.parent[idx] != root {
+		uf.parent[idx], idx = root, uf.parent[idx]
+	}
+	return root
+}
+
+// Union merges the sets containing a and b, adding either if it's new. It returns false if
+// they were already in the same set.
+func (uf *UnionFind[T]) Union(a, b T) bool {
+	uf.Add(a)
+	uf.Add(b)
+	rootA, rootB := uf.root(uf.index[a]), uf.root(uf.index[b])
+	if rootA == rootB {
+		return false
+	}
+	if uf.size[rootA] < uf.size[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+	uf.parent[rootB] = rootA
+	uf.size[rootA] += uf.size[rootB]
+	uf.count--
+	return true
+}
+
+// Connected returns true if a and b have been added and are in the same set.
+func (uf *UnionFind[T]) Connected(a, b T) bool {
+	rootA, okA := uf.Find(a)
+	rootB, okB := uf.Find(b)
+	return okA && okB && rootA == rootB
+}
+
+// Size returns how many items are in the set containing item, or 0 if it was never added.
+func (uf *UnionFind[T]) Size(item T) int {
+	idx, ok := uf.index[item]
+	if !ok {
+		return 0
+	}
+	return uf.size[uf.root(idx)]
+}
+
+// Len returns the number of items added.
+func (uf *UnionFind[T]) Len() int { return len(uf.items) }
+
+// Count returns the number of disjoint sets.
+func (uf *UnionFind[T]) Count() int { return uf.count }
+
+// Components returns the items grouped by set. Sets are ordered by their earliest added item,
+// and items within a set are in the order they were added.
+func (uf *UnionFind[T]) Components() [][]T {
+	out := make([][]T, 0, uf.count)
+	rootToComponent := make(map[int]int, uf.count)
+	for idx, item := range uf.items {
+		root := uf.root(idx)
+		component, ok := rootToComponent[root]
+		if !ok {
+			component = len(out)
+			rootToComponent[root] = component
+			out = append(out, make([]T, 0, uf.size[root]))
+		}
+		out[component] = append(out[component], item)
+	}
+	return out
+}
+
+// Sizes returns the size of every set, largest first.
+func (uf *UnionFind[T]) Sizes() []int {
+	out := make([]int, 0, uf.count)
+	for idx := range uf.items {
+		if uf.parent[idx] == idx {
+			out = append(out, uf.size[idx])
+		}
+	}
+	slices.SortFunc(out, func(a, b int) int { return cmp.Compare(b, a) })
+	return out
+}
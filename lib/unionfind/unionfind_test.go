@@ -0,0 +1,75 @@
+package unionfind_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jstensland/advent-of-code/lib/unionfind"
+)
+
+func TestUnionFind(t *testing.T) {
+	uf := unionfind.New("a", "b", "c", "d", "e")
+
+	assert.Equal(t, 5, uf.Count())
+	assert.Equal(t, 5, uf.Len())
+	assert.False(t, uf.Add("a"), "already added")
+
+	assert.True(t, uf.Union("a", "b"))
+	assert.True(t, uf.Union("d", "c"))
+	assert.True(t, uf.Union("b", "d"))
+	assert.False(t, uf.Union("a", "c"), "already connected")
+
+	assert.Equal(t, 2, uf.Count())
+	assert.True(t, uf.Connected("a", "c"))
+	assert.False(t, uf.Connected("a", "e"))
+	assert.False(t, uf.Connected("a", "missing"))
+	assert.Equal(t, 4, uf.Size("c"))
+	assert.Equal(t, 1, uf.Size("e"))
+	assert.Equal(t, 0, uf.Size("missing"))
+
+	rootA, ok := uf.Find("a")
+	assert.True(t, ok)
+	rootD, _ := uf.Find("d")
+	assert.Equal(t, rootA, rootD)
+	_, ok = uf.Find("missing")
+	assert.False(t, ok)
+
+	assert.Equal(t, [][]string{{"a", "b", "c", "d"}, {"e"}}, uf.Components())
+	assert.Equal(t, []int{4, 1}, uf.Sizes())
+}
+
+func TestUnion_AddsNewItems(t *testing.T) {
+	uf := unionfind.New[int]()
+
+	assert.True(t, uf.Union(1, 2))
+	assert.True(t, uf.Contains(1))
+	assert.True(t, uf.Contains(2))
+	assert.False(t, uf.Contains(3))
+	assert.Equal(t, 1, uf.Count())
+	assert.Equal(t, 2, uf.Len())
+}
+
+func TestChain(t *testing.T) {
+	// a long chain stays correct as paths are compressed
+	const n = 10_000
+	uf := unionfind.New[int]()
+	for i := 1; i < n; i++ {
+		uf.Union(i-1, i)
+	}
+
+	assert.Equal(t, 1, uf.Count())
+	assert.Equal(t, n, uf.Size(0))
+	assert.True(t, uf.Connected(0, n-1))
+	assert.Equal(t, []int{n}, uf.Sizes())
+}
+
+func BenchmarkUnion(b *testing.B) {
+	const n = 100_000
+	for b.Loop() {
+		uf := unionfind.New[int]()
+		for i := range n {
+			uf.Union(i, (i*7919)%n)
+		}
+	}
+}
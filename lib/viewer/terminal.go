@@ -0,0 +1,34 @@
+package viewer
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Terminal puts the terminal on f in raw mode with stty, so keys arrive one at a time without
+// echoing. It returns a function that puts the terminal back how it was.
+func Terminal(f *os.File) (func() error, error) {
+	saved, err := stty(f, "-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty(f, "-icanon", "-echo", "min", "1"); err != nil {
+		return nil, err
+	}
+	return func() error {
+		_, err := stty(f, strings.TrimSpace(saved))
+		return err
+	}, nil
+}
+
+func stty(f *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...) //nolint:gosec // args are stty settings, not user input
+	cmd.Stdin = f
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("error running stty %s: %w", strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,238 @@
+// Package viewer plays simulations in the terminal, so a day can be watched move by move rather
+// than by sprinkling prints through it.
+//
+// A simulation is a step function, which advances it and reports false once it is over, and a
+// render function, which draws it as text. The viewer keeps every frame drawn so far, so it can
+// step backwards as well as forwards. Drawing uses plain ANSI escapes.
+//
+// Headless steps through a simulation without a terminal and returns the frames, for tests.
+package viewer
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDelay = 200 * time.Millisecond
+	minDelay     = 10 * time.Millisecond
+	maxDelay     = 5 * time.Second
+
+	clearScreen = "\x1b[H\x1b[2J"
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+)
+
+// Key is a command for the viewer.
+type Key int
+
+const (
+	KeyNone    Key = iota
+	KeyPlay        // space: play or pause
+	KeyForward     // right arrow, l or .
+	KeyBack        // left arrow, h or ,
+	KeyFaster      // + or =
+	KeySlower      // -
+	KeyQuit        // q or ctrl-c
+)
+
+// Viewer shows one frame of a simulation at a time.
+type Viewer struct {
+	step    func() bool
+	render  func() string
+	frames  []string // every frame drawn so far, oldest first
+	pos     int      // index of the frame on screen
+	ended   bool     // step has reported the simulation is over
+	playing bool
+	delay   time.Duration
+}
+
+// New returns a paused Viewer showing the simulation as it is now.
+func New(step func() bool, render func() string) *Viewer {
+	return &Viewer{step: step, render: render, frames: []string{render()}, delay: defaultDelay}
+}
+
+// Frame is the frame on screen.
+func (v *Viewer) Frame() string {
+	return v.frames[v.pos]
+}
+
+// Index is the position of the frame on screen, starting from 0 for the first.
+func (v *Viewer) Index() int {
+	return v.pos
+}
+
+// Playing reports whether frames advance on their own.
+func (v *Viewer) Playing() bool {
+	return v.playing
+}
+
+// Delay is how long each frame shows while playing.
+func (v *Viewer) Delay() time.Duration {
+	return v.delay
+}
+
+// Forward shows the next frame, stepping the simulation if it has not been seen yet. It
+// returns false, staying put, once the simulation is over.
+func (v *Viewer) Forward() bool {
+	if v.pos < len(v.frames)-1 {
+		v.pos++
+		return true
+	}
+	if v.ended || !v.step() {
+		v.ended = true
+		return false
+	}
+	v.frames = append(v.frames, v.render())
+	v.pos++
+	return true
+}
+
+// Back shows the previous frame. It returns false on the first frame.
+func (v *Viewer) Back() bool {
+	if v.pos == 0 {
+		return false
+	}
+	v.pos--
+	return true
+}
+
+// Handle applies a key. It returns true if the key asks to quit.
+func (v *Viewer) Handle(key Key) bool {
+	switch key {
+	case KeyPlay:
+		v.playing = !v.playing
+	case KeyForward:
+		v.playing = false
+		v.Forward()
+	case KeyBack:
+		v.playing = false
+		v.Back()
+	case KeyFaster:
+		v.delay = max(v.delay/2, minDelay)
+	case KeySlower:
+		v.delay = min(v.delay*2, maxDelay)
+	case KeyQuit:
+		return true
+	case KeyNone:
+	}
+	return false
+}
+
+// Headless steps through the simulation without drawing anything, stopping when it ends or
+// after maxSteps steps. It returns every frame from the first.
+func (v *Viewer) Headless(maxSteps int) []string {
+	for range maxSteps {
+		if !v.Forward() {
+			break
+		}
+	}
+	return v.frames[:len(v.frames):len(v.frames)]
+}
+
+// Run draws the simulation to out and reacts to keys read from in until a quit key, the end of
+// in, or the context is done. Playing pauses by itself at the end of the simulation.
+//
+// in should be a terminal in raw mode, see Terminal, so keys arrive without waiting for enter.
+// The goroutine reading in is left blocked on it after Run returns.
+func (v *Viewer) Run(ctx context.Context, in io.Reader, out io.Writer) error {
+	keys := make(chan Key)
+	go readKeys(in, keys)
+
+	fmt.Fprint(out, hideCursor)
+	defer fmt.Fprint(out, showCursor)
+
+	for {
+		v.draw(out)
+
+		var tick <-chan time.Time
+		if v.playing {
+			tick = time.After(v.delay)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("viewer stopped: %w", ctx.Err())
+		case key, ok := <-keys:
+			if !ok || v.Handle(key) {
+				return nil
+			}
+		case <-tick:
+			if !v.Forward() {
+				v.playing = false
+			}
+		}
+	}
+}
+
+func (v *Viewer) draw(out io.Writer) {
+	state := "paused"
+	if v.playing {
+		state = "playing"
+	}
+	if v.ended && v.pos == len(v.frames)-1 {
+		state += ", end"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(clearScreen)
+	sb.WriteString(v.Frame())
+	if !strings.HasSuffix(v.Frame(), "\n") {
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "frame %d/%d  %s  %v per frame\n", v.pos+1, len(v.frames), state, v.delay)
+	sb.WriteString("[space] play/pause  [←/→] step  [+/-] speed  [q] quit\n")
+	fmt.Fprint(out, sb.String())
+}
+
+// readKeys sends each key read from in, closing keys at the end of in.
+func readKeys(in io.Reader, keys chan<- Key) {
+	defer close(keys)
+	r := bufio.NewReader(in)
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return
+		}
+		key := parseKey(b, r)
+		if key != KeyNone {
+			keys <- key
+		}
+	}
+}
+
+// parseKey turns a byte into a Key, reading the rest of an escape sequence from r if needed.
+func parseKey(b byte, r *bufio.Reader) Key {
+	switch b {
+	case ' ':
+		return KeyPlay
+	case 'l', '.':
+		return KeyForward
+	case 'h', ',':
+		return KeyBack
+	case '+', '=':
+		return KeyFaster
+	case '-':
+		return KeySlower
+	case 'q', 3: //nolint:mnd // 3 is ctrl-c
+		return KeyQuit
+	case 0x1b: //nolint:mnd // escape, as in arrow keys ESC [ C
+		if next, err := r.ReadByte(); err != nil || next != '[' {
+			return KeyNone
+		}
+		arrow, err := r.ReadByte()
+		if err != nil {
+			return KeyNone
+		}
+		switch arrow {
+		case 'C':
+			return KeyForward
+		case 'D':
+			return KeyBack
+		}
+	}
+	return KeyNone
+}
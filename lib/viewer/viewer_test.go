@@ -0,0 +1,140 @@
+package viewer_test
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jstensland/advent-of-code/lib/viewer"
+)
+
+// counter is a simulation that counts up to limit
+type counter struct {
+	n, limit int
+	steps    int
+}
+
+func (c *counter) step() bool {
+	if c.n == c.limit {
+		return false
+	}
+	c.n++
+	c.steps++
+	return true
+}
+
+func (c *counter) render() string {
+	return strconv.Itoa(c.n)
+}
+
+func newCounter(limit int) (*counter, *viewer.Viewer) {
+	c := &counter{limit: limit}
+	return c, viewer.New(c.step, c.render)
+}
+
+func TestHeadless(t *testing.T) {
+	_, v := newCounter(3)
+
+	assert.Equal(t, []string{"0", "1", "2", "3"}, v.Headless(10))
+
+	_, v = newCounter(3)
+	assert.Equal(t, []string{"0", "1"}, v.Headless(1))
+}
+
+func TestForwardBack(t *testing.T) {
+	c, v := newCounter(2)
+
+	assert.False(t, v.Back(), "nothing before the first frame")
+	assert.True(t, v.Forward())
+	assert.True(t, v.Forward())
+	assert.Equal(t, "2", v.Frame())
+	assert.False(t, v.Forward(), "simulation is over")
+	assert.False(t, v.Forward())
+
+	assert.True(t, v.Back())
+	assert.True(t, v.Back())
+	assert.Equal(t, "0", v.Frame())
+	assert.Equal(t, 0, v.Index())
+
+	// going forward again replays frames rather than stepping
+	assert.True(t, v.Forward())
+	assert.Equal(t, "1", v.Frame())
+	assert.Equal(t, 2, c.steps)
+}
+
+func TestHandle(t *testing.T) {
+	_, v := newCounter(5)
+	start := v.Delay()
+
+	assert.False(t, v.Handle(viewer.KeyPlay))
+	assert.True(t, v.Playing())
+	v.Handle(viewer.KeyForward)
+	assert.False(t, v.Playing(), "stepping pauses")
+	assert.Equal(t, "1", v.Frame())
+	v.Handle(viewer.KeyBack)
+	assert.Equal(t, "0", v.Frame())
+
+	v.Handle(viewer.KeyFaster)
+	assert.Equal(t, start/2, v.Delay())
+	v.Handle(viewer.KeySlower)
+	v.Handle(viewer.KeySlower)
+	assert.Equal(t, start*2, v.Delay())
+	for range 20 {
+		v.Handle(viewer.KeyFaster)
+	}
+	assert.Positive(t, v.Delay(), "speed is capped")
+
+	assert.True(t, v.Handle(viewer.KeyQuit))
+}
+
+func TestRun_Keys(t *testing.T) {
+	_, v := newCounter(5)
+	// right arrow, l, left arrow, then quit. Unknown keys are ignored.
+	in := strings.NewReader("\x1b[Clx\x1b[Dq")
+	var out bytes.Buffer
+
+	err := v.Run(context.Background(), in, &out)
+
+	require.NoError(t, err)
+	assert.Equal(t, "1", v.Frame())
+	screens := strings.Split(out.String(), "\x1b[H\x1b[2J")[1:]
+	require.Len(t, screens, 4, "a screen to start and one per key before quit")
+	assert.True(t, strings.HasPrefix(screens[2], "2\nframe 3/3  paused"), screens[2])
+	assert.Contains(t, out.String(), "\x1b[?25h", "cursor is shown again")
+}
+
+func TestRun_EndOfInput(t *testing.T) {
+	_, v := newCounter(5)
+
+	err := v.Run(context.Background(), strings.NewReader(".."), &bytes.Buffer{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "2", v.Frame())
+}
+
+// blocked is a reader that never has anything to read, like an idle terminal
+type blocked struct{}
+
+func (blocked) Read([]byte) (int, error) { select {} }
+
+func TestRun_PlaysToTheEnd(t *testing.T) {
+	_, v := newCounter(3)
+	for range 10 {
+		v.Handle(viewer.KeyFaster)
+	}
+	v.Handle(viewer.KeyPlay)
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	err := v.Run(ctx, blocked{}, &bytes.Buffer{})
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "3", v.Frame())
+	assert.False(t, v.Playing(), "pauses at the end")
+}